@@ -48,6 +48,7 @@ type Account struct {
 	name         string
 	timeRange    gtime.Range
 	currencyCode currency.Code
+	accountType  Type
 }
 
 // Name returns the name associated with a given Account.
@@ -84,6 +85,11 @@ func (a Account) OpenAt(t time.Time) bool {
 	return true
 }
 
+// Type returns the Type of the Account.
+func (a Account) Type() Type {
+	return a.accountType
+}
+
 // CurrencyCode returns the currency code of the Account.
 func (a Account) CurrencyCode() currency.Code {
 	return a.currencyCode
@@ -95,6 +101,9 @@ func (a Account) validate() (err error) {
 	if len(a.name) == 0 {
 		fieldErrorDescriptions = append(fieldErrorDescriptions, EmptyNameError)
 	}
+	if !a.accountType.Valid() {
+		fieldErrorDescriptions = append(fieldErrorDescriptions, InvalidTypeError)
+	}
 	if len(fieldErrorDescriptions) > 0 {
 		err = FieldError(fieldErrorDescriptions)
 	}
@@ -105,11 +114,18 @@ func (a Account) validate() (err error) {
 // ValidateBalance returns any logical errors between the Account and the balance.
 // ValidateBalance first attempts to validate the Account as an entity by itself. If there are any errors with the Account, these errors are returned and the balance is not attempted to be validated against the Account.
 // If the date of the balance is outside of the TimeRange of the Account, a DateOutOfAccountTimeRange will be returned.
+// If the balance has a currency that is not the currency of the Account, a CurrencyMismatch will be returned.
 func (a Account) ValidateBalance(b balance.Balance) (err error) {
 	err = a.validate()
 	if err != nil {
 		return
 	}
+	if b.HasCurrency() && b.Currency != a.currencyCode {
+		return balance.CurrencyMismatch{
+			BalanceCurrency: b.Currency,
+			AccountCurrency: a.currencyCode,
+		}
+	}
 	if !a.timeRange.Contains(b.Date) && (!a.Closed().Valid || !a.Closed().Time.Equal(b.Date)) {
 		return balance.DateOutOfAccountTimeRange{
 			BalanceDate:      b.Date,
@@ -119,22 +135,41 @@ func (a Account) ValidateBalance(b balance.Balance) (err error) {
 	return
 }
 
+// ClipBalances returns the Balances that have a Date within the lifetime of
+// the Account, in the order that they are held.
+// As with ValidateBalance, a Balance at the exact time that the Account was
+// closed is kept, so that a closing Balance is not lost.
+func (a Account) ClipBalances(bs balance.Balances) balance.Balances {
+	var clipped balance.Balances
+	for _, b := range bs {
+		if a.OpenAt(b.Date) || a.Closed().Valid && a.Closed().Time.Equal(b.Date) {
+			clipped = append(clipped, b)
+		}
+	}
+	return clipped
+}
+
 // MarshalJSON marshals an Account into a json blob, returning the blob with any errors that occur during the marshalling.
 func (a Account) MarshalJSON() ([]byte, error) {
 	type Alias Account
-	return json.Marshal(&struct {
+	aux := &struct {
 		*Alias
 		Name     string
 		Opened   time.Time
 		Closed   gtime.NullTime
 		Currency currency.Code
+		Type     string `json:",omitempty"`
 	}{
 		Alias:    (*Alias)(&a),
 		Name:     a.Name(),
 		Opened:   a.Opened(),
 		Closed:   a.Closed(),
 		Currency: a.currencyCode,
-	})
+	}
+	if a.accountType != Unclassified {
+		aux.Type = a.accountType.String()
+	}
+	return json.Marshal(aux)
 }
 
 // UnmarshalJSON attempts to unmarshal a json blob into an Account object,
@@ -146,6 +181,7 @@ func (a *Account) UnmarshalJSON(data []byte) (err error) {
 		Opened   time.Time
 		Closed   gtime.NullTime
 		Currency string
+		Type     string
 		*Alias
 	}{
 		Alias: (*Alias)(a),
@@ -171,6 +207,13 @@ func (a *Account) UnmarshalJSON(data []byte) (err error) {
 		}
 	}
 	a.timeRange = *tr
+	a.accountType = Unclassified
+	if aux.Type != "" {
+		a.accountType, err = ParseType(aux.Type)
+		if err != nil {
+			return errors.Wrap(err, "parsing Account type")
+		}
+	}
 	return a.validate()
 }
 
@@ -191,6 +234,8 @@ func (a Account) Equal(b Account) bool {
 		return false
 	case !a.timeRange.Equal(b.TimeRange()):
 		return false
+	case a.accountType != b.Type():
+		return false
 	}
 	return true
 }
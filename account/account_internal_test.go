@@ -314,3 +314,38 @@ func newTestTimeRange(t *testing.T, os ...gtime.Option) gtime.Range {
 	common.FatalIfError(t, err, "Creating time.Range")
 	return *r
 }
+
+func Test_AccountValidateBalanceCurrency(t *testing.T) {
+	present := time.Now()
+	a := Account{
+		name:         "Test Account",
+		timeRange:    newTestTimeRange(t, gtime.Start(present)),
+		currencyCode: newTestCurrency(t, "GBP"),
+	}
+	for _, test := range []struct {
+		name    string
+		options []balance.Option
+		error
+	}{
+		{
+			name: "without currency",
+		},
+		{
+			name:    "with account currency",
+			options: []balance.Option{balance.CurrencyCode(newTestCurrency(t, "GBP"))},
+		},
+		{
+			name:    "with other currency",
+			options: []balance.Option{balance.CurrencyCode(newTestCurrency(t, "EUR"))},
+			error: balance.CurrencyMismatch{
+				BalanceCurrency: newTestCurrency(t, "EUR"),
+				AccountCurrency: newTestCurrency(t, "GBP"),
+			},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			err := a.ValidateBalance(newTestBalance(t, present, test.options...))
+			assert.Equal(t, test.error, err)
+		})
+	}
+}
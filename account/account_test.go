@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/glynternet/go-accounting/account"
+	"github.com/glynternet/go-accounting/balance"
 	"github.com/glynternet/go-money/common"
 	"github.com/glynternet/go-money/currency"
 	"github.com/stretchr/testify/assert"
@@ -50,6 +51,16 @@ func TestAccount_MarshalJSON(t *testing.T) {
 	c, err := account.UnmarshalJSON(bytes)
 	common.FatalIfError(t, err, "Unmarshalling Account json b")
 	assert.True(t, c.Equal(*a), "bytes: %s", bytes)
+
+	err = account.OfType(account.Expense)(a)
+	assert.Nil(t, err)
+	bytes, err = json.Marshal(&a)
+	common.FatalIfError(t, err, "Marshalling json")
+
+	d, err := account.UnmarshalJSON(bytes)
+	common.FatalIfError(t, err, "Unmarshalling Account json c")
+	assert.Equal(t, account.Expense, d.Type(), "bytes: %s", bytes)
+	assert.True(t, d.Equal(*a), "bytes: %s", bytes)
 }
 
 func TestAccount_Equal(t *testing.T) {
@@ -93,6 +104,14 @@ func TestAccount_Equal(t *testing.T) {
 			},
 			equal: false,
 		},
+		{
+			name: "A",
+			open: now,
+			options: []account.Option{
+				account.OfType(account.Asset),
+			},
+			equal: false,
+		},
 	} {
 		b := newTestAccount(t, test.name, newTestCurrency(t, "EUR"), test.open, test.options...)
 		assert.Nil(t, err, "Error creating account")
@@ -111,3 +130,32 @@ func newTestCurrency(t *testing.T, code string) currency.Code {
 	common.FatalIfError(t, err, "Creating Currency Code")
 	return *c
 }
+
+func TestAccount_ClipBalances(t *testing.T) {
+	open := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	close := time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC)
+	bs := balance.Balances{
+		{Date: close, Amount: 6},
+		{Date: open.Add(-1), Amount: 0},
+		{Date: open, Amount: 1},
+		{Date: close.Add(1), Amount: 7},
+		{Date: close.Add(-1), Amount: 5},
+	}
+
+	a := newTestAccount(t, "A", newTestCurrency(t, "EUR"), open, account.CloseTime(close))
+	assert.Equal(t, balance.Balances{
+		{Date: close, Amount: 6},
+		{Date: open, Amount: 1},
+		{Date: close.Add(-1), Amount: 5},
+	}, a.ClipBalances(bs))
+
+	a = newTestAccount(t, "A", newTestCurrency(t, "EUR"), open)
+	assert.Equal(t, balance.Balances{
+		{Date: close, Amount: 6},
+		{Date: open, Amount: 1},
+		{Date: close.Add(1), Amount: 7},
+		{Date: close.Add(-1), Amount: 5},
+	}, a.ClipBalances(bs))
+
+	assert.Empty(t, a.ClipBalances(nil))
+}
@@ -0,0 +1,191 @@
+package account
+
+import (
+	"errors"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/glynternet/go-accounting/balance"
+)
+
+// PathSeparator is the separator used between Account names in the paths of a ChartOfAccounts.
+// The Account at path "Bank:Current" is the child of the Account at path "Bank".
+const PathSeparator = ":"
+
+// Various error strings describing possible errors when using a ChartOfAccounts.
+const (
+	ErrPathNotFound     = "path not found in ChartOfAccounts"
+	ErrPathExists       = "path already exists in ChartOfAccounts"
+	ErrNameContainsPath = "Account name contains PathSeparator"
+	ErrTypeMismatch     = "Account Type does not match parent Account Type"
+	ErrCurrencyMismatch = "Account currency does not match parent Account currency"
+)
+
+// NewChartOfAccounts creates a new, empty ChartOfAccounts.
+func NewChartOfAccounts() *ChartOfAccounts {
+	return &ChartOfAccounts{nodes: make(map[string]*chartNode)}
+}
+
+// ChartOfAccounts holds a hierarchy of Accounts, each identified by a path
+// made up of the names of the Account and its ancestors, joined by
+// PathSeparator.
+// Each Account in a ChartOfAccounts can hold Balances, which are rolled up
+// into the totals of its ancestors.
+type ChartOfAccounts struct {
+	nodes map[string]*chartNode
+	roots []string
+}
+
+type chartNode struct {
+	account  Account
+	parent   string
+	children []string
+	balances balance.Balances
+}
+
+// Add adds an Account to the ChartOfAccounts as a child of the Account at the
+// parent path, returning the path of the added Account.
+// An empty parent path will add the Account at the top level of the ChartOfAccounts.
+// An Account can only be added beneath a parent of the same currency and, if
+// both Accounts are classified, the same Type.
+func (c *ChartOfAccounts) Add(parent string, a Account) (string, error) {
+	if strings.Contains(a.Name(), PathSeparator) {
+		return "", errors.New(ErrNameContainsPath)
+	}
+	path := a.Name()
+	if parent != "" {
+		p, ok := c.nodes[parent]
+		if !ok {
+			return "", errors.New(ErrPathNotFound)
+		}
+		if p.account.CurrencyCode() != a.CurrencyCode() {
+			return "", errors.New(ErrCurrencyMismatch)
+		}
+		if p.account.Type() != Unclassified && a.Type() != Unclassified && p.account.Type() != a.Type() {
+			return "", errors.New(ErrTypeMismatch)
+		}
+		path = parent + PathSeparator + path
+	}
+	if _, ok := c.nodes[path]; ok {
+		return "", errors.New(ErrPathExists)
+	}
+	if c.nodes == nil {
+		c.nodes = make(map[string]*chartNode)
+	}
+	c.nodes[path] = &chartNode{account: a, parent: parent}
+	if parent == "" {
+		c.roots = append(c.roots, path)
+	} else {
+		c.nodes[parent].children = append(c.nodes[parent].children, path)
+	}
+	return path, nil
+}
+
+// Account returns the Account at the given path.
+func (c ChartOfAccounts) Account(path string) (Account, error) {
+	n, ok := c.nodes[path]
+	if !ok {
+		return Account{}, errors.New(ErrPathNotFound)
+	}
+	return n.account, nil
+}
+
+// Parent returns the path of the parent of the Account at the given path.
+// An Account at the top level of the ChartOfAccounts has an empty parent path.
+func (c ChartOfAccounts) Parent(path string) (string, error) {
+	n, ok := c.nodes[path]
+	if !ok {
+		return "", errors.New(ErrPathNotFound)
+	}
+	return n.parent, nil
+}
+
+// Children returns the paths of the direct children of the Account at the
+// given path, in the order that they were added.
+// An empty path will return the paths of the top level Accounts.
+func (c ChartOfAccounts) Children(path string) ([]string, error) {
+	if path == "" {
+		return append([]string(nil), c.roots...), nil
+	}
+	n, ok := c.nodes[path]
+	if !ok {
+		return nil, errors.New(ErrPathNotFound)
+	}
+	return append([]string(nil), n.children...), nil
+}
+
+// SetBalances sets the Balances held by the Account at the given path.
+// Each Balance is validated through Account.ValidateBalance and the first
+// validation error encountered is returned, leaving the ChartOfAccounts unaltered.
+func (c *ChartOfAccounts) SetBalances(path string, bs balance.Balances) error {
+	n, ok := c.nodes[path]
+	if !ok {
+		return errors.New(ErrPathNotFound)
+	}
+	for _, b := range bs {
+		if err := n.account.ValidateBalance(b); err != nil {
+			return err
+		}
+	}
+	n.balances = append(balance.Balances(nil), bs...)
+	return nil
+}
+
+// Balances returns the Balances held directly by the Account at the given path.
+func (c ChartOfAccounts) Balances(path string) (balance.Balances, error) {
+	n, ok := c.nodes[path]
+	if !ok {
+		return nil, errors.New(ErrPathNotFound)
+	}
+	return append(balance.Balances(nil), n.balances...), nil
+}
+
+// RolledUp returns the Balances of the Account at the given path combined
+// with the Balances of all of its descendants.
+// A Balance is returned for each distinct Date found across the Account and
+// its descendants, in Date order. The Amount of each is the sum of the
+// Balances of each of those Accounts at that Date, as given by
+// Balances.AtTime, in the currency of the Account at the given path.
+// Accounts with no Balance at or before a Date do not
+// contribute to the total at that Date.
+func (c ChartOfAccounts) RolledUp(path string) (balance.Balances, error) {
+	n, ok := c.nodes[path]
+	if !ok {
+		return nil, errors.New(ErrPathNotFound)
+	}
+	var series []balance.Balances
+	var dates []time.Time
+	c.walk(n, func(n *chartNode) {
+		series = append(series, n.balances)
+		for _, b := range n.balances {
+			dates = append(dates, b.Date)
+		}
+	})
+	sort.Slice(dates, func(i, j int) bool { return dates[i].Before(dates[j]) })
+	var rolled balance.Balances
+	for i, d := range dates {
+		if i > 0 && d.Equal(dates[i-1]) {
+			continue
+		}
+		var total int
+		for _, bs := range series {
+			if b, err := bs.AtTime(d); err == nil {
+				total += b.Amount
+			}
+		}
+		rolled = append(rolled, balance.Balance{
+			Date:     d,
+			Amount:   total,
+			Currency: n.account.CurrencyCode(),
+		})
+	}
+	return rolled, nil
+}
+
+func (c ChartOfAccounts) walk(n *chartNode, fn func(*chartNode)) {
+	fn(n)
+	for _, child := range n.children {
+		c.walk(c.nodes[child], fn)
+	}
+}
@@ -0,0 +1,159 @@
+package account_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/glynternet/go-accounting/account"
+	"github.com/glynternet/go-accounting/balance"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChartOfAccounts_Add(t *testing.T) {
+	open := time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+	c := account.NewChartOfAccounts()
+
+	bank := newTestAccount(t, "Bank", newTestCurrency(t, "GBP"), open, account.OfType(account.Asset))
+	path, err := c.Add("", bank)
+	assert.Nil(t, err)
+	assert.Equal(t, "Bank", path)
+
+	current := newTestAccount(t, "Current", newTestCurrency(t, "GBP"), open)
+	path, err = c.Add("Bank", current)
+	assert.Nil(t, err)
+	assert.Equal(t, "Bank:Current", path)
+
+	for _, test := range []struct {
+		name   string
+		parent string
+		account.Account
+		err string
+	}{
+		{
+			name:    "missing parent",
+			parent:  "Loans",
+			Account: newTestAccount(t, "Mortgage", newTestCurrency(t, "GBP"), open),
+			err:     account.ErrPathNotFound,
+		},
+		{
+			name:    "existing path",
+			parent:  "Bank",
+			Account: current,
+			err:     account.ErrPathExists,
+		},
+		{
+			name:    "name containing separator",
+			Account: newTestAccount(t, "Bank:Savings", newTestCurrency(t, "GBP"), open),
+			err:     account.ErrNameContainsPath,
+		},
+		{
+			name:    "different currency",
+			parent:  "Bank",
+			Account: newTestAccount(t, "Euro", newTestCurrency(t, "EUR"), open),
+			err:     account.ErrCurrencyMismatch,
+		},
+		{
+			name:    "different type",
+			parent:  "Bank",
+			Account: newTestAccount(t, "Card", newTestCurrency(t, "GBP"), open, account.OfType(account.Liability)),
+			err:     account.ErrTypeMismatch,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := c.Add(test.parent, test.Account)
+			assert.Equal(t, errors.New(test.err), err)
+		})
+	}
+}
+
+func TestChartOfAccounts_Lookups(t *testing.T) {
+	open := time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+	var c account.ChartOfAccounts
+	for _, a := range []struct {
+		parent, name string
+	}{
+		{name: "Bank"},
+		{parent: "Bank", name: "Current"},
+		{parent: "Bank", name: "Savings"},
+		{parent: "Bank:Savings", name: "ISA"},
+		{name: "Cash"},
+	} {
+		_, err := c.Add(a.parent, newTestAccount(t, a.name, newTestCurrency(t, "GBP"), open))
+		assert.Nil(t, err)
+	}
+
+	a, err := c.Account("Bank:Savings:ISA")
+	assert.Nil(t, err)
+	assert.Equal(t, "ISA", a.Name())
+	_, err = c.Account("Bank:ISA")
+	assert.Equal(t, errors.New(account.ErrPathNotFound), err)
+
+	parent, err := c.Parent("Bank:Savings:ISA")
+	assert.Nil(t, err)
+	assert.Equal(t, "Bank:Savings", parent)
+	parent, err = c.Parent("Bank")
+	assert.Nil(t, err)
+	assert.Equal(t, "", parent)
+
+	children, err := c.Children("")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"Bank", "Cash"}, children)
+	children, err = c.Children("Bank")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"Bank:Current", "Bank:Savings"}, children)
+	_, err = c.Children("Nowhere")
+	assert.Equal(t, errors.New(account.ErrPathNotFound), err)
+}
+
+func TestChartOfAccounts_RolledUp(t *testing.T) {
+	date := func(month time.Month) time.Time {
+		return time.Date(2000, month, 1, 0, 0, 0, 0, time.UTC)
+	}
+	c := account.NewChartOfAccounts()
+	for _, a := range []struct {
+		parent, name string
+		account.Option
+	}{
+		{name: "Bank"},
+		{parent: "Bank", name: "Current"},
+		{parent: "Bank", name: "Savings", Option: account.CloseTime(date(6))},
+	} {
+		_, err := c.Add(a.parent, newTestAccount(t, a.name, newTestCurrency(t, "GBP"), date(1), a.Option))
+		assert.Nil(t, err)
+	}
+
+	assert.Nil(t, c.SetBalances("Bank:Current", balance.Balances{
+		{Date: date(2), Amount: 100},
+		{Date: date(4), Amount: 50},
+	}))
+	assert.Nil(t, c.SetBalances("Bank:Savings", balance.Balances{
+		{Date: date(3), Amount: 1000},
+	}))
+	assert.IsType(t,
+		balance.DateOutOfAccountTimeRange{},
+		c.SetBalances("Bank:Savings", balance.Balances{{Date: date(7)}}),
+	)
+	bs, err := c.Balances("Bank:Savings")
+	assert.Nil(t, err)
+	assert.Equal(t, balance.Balances{{Date: date(3), Amount: 1000}}, bs)
+
+	gbp := newTestCurrency(t, "GBP")
+	rolled, err := c.RolledUp("Bank")
+	assert.Nil(t, err)
+	assert.Equal(t, balance.Balances{
+		{Date: date(2), Amount: 100, Currency: gbp},
+		{Date: date(3), Amount: 1100, Currency: gbp},
+		{Date: date(4), Amount: 1050, Currency: gbp},
+	}, rolled)
+
+	rolled, err = c.RolledUp("Bank:Current")
+	assert.Nil(t, err)
+	assert.Equal(t, balance.Balances{
+		{Date: date(2), Amount: 100, Currency: gbp},
+		{Date: date(4), Amount: 50, Currency: gbp},
+	}, rolled)
+
+	_, err = c.RolledUp("Nowhere")
+	assert.Equal(t, errors.New(account.ErrPathNotFound), err)
+}
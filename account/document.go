@@ -0,0 +1,74 @@
+package account
+
+import (
+	"encoding/json"
+
+	"github.com/glynternet/go-accounting/balance"
+	"github.com/pkg/errors"
+)
+
+// DocumentVersion is the version of the Document json schema that is produced by Document.MarshalJSON.
+const DocumentVersion = 1
+
+// ErrUnsupportedDocumentVersion is the error message used when unmarshalling a Document with an unknown version.
+const ErrUnsupportedDocumentVersion = "unsupported Document version"
+
+// Document holds an Account along with the Balances that belong to it.
+type Document struct {
+	Account  Account
+	Balances balance.Balances
+}
+
+// MarshalJSON marshals a Document into a json blob, returning the blob with any errors that occur during the marshalling.
+//
+// A Document is marshalled as an object with the following fields:
+//
+//	Version  number, the DocumentVersion of the schema
+//	Account  object, the Account as marshalled by Account.MarshalJSON
+//	Balances array, the Balances as marshalled by Balance.MarshalJSON
+func (d Document) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Version  int
+		Account  Account
+		Balances balance.Balances
+	}{
+		Version:  DocumentVersion,
+		Account:  d.Account,
+		Balances: d.Balances,
+	})
+}
+
+// UnmarshalJSON attempts to unmarshal a json blob into a Document object,
+// returning any errors that occur during the unmarshalling.
+// Every Balance of the Document is validated through Account.ValidateBalance.
+func (d *Document) UnmarshalJSON(data []byte) error {
+	var aux struct {
+		Version  int
+		Account  Account
+		Balances balance.Balances
+	}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return errors.Wrap(err, "unmarshalling data to auxilliary")
+	}
+	if aux.Version != DocumentVersion {
+		return errors.Wrapf(errors.New(ErrUnsupportedDocumentVersion), "version %d", aux.Version)
+	}
+	for i, b := range aux.Balances {
+		if err := aux.Account.ValidateBalance(b); err != nil {
+			return errors.Wrapf(err, "validating Balance at index %d", i)
+		}
+	}
+	d.Account = aux.Account
+	d.Balances = aux.Balances
+	return nil
+}
+
+// UnmarshalDocumentJSON unmarshals json data into a Document
+func UnmarshalDocumentJSON(data []byte) (*Document, error) {
+	var d Document
+	err := json.Unmarshal(data, &d)
+	if err != nil {
+		return nil, err
+	}
+	return &d, nil
+}
@@ -0,0 +1,95 @@
+package account_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/glynternet/go-accounting/account"
+	"github.com/glynternet/go-accounting/balance"
+	"github.com/glynternet/go-money/common"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDocument_JSONLoop(t *testing.T) {
+	open := time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+	gbp := newTestCurrency(t, "GBP")
+	d := account.Document{
+		Account: newTestAccount(t, "A", gbp, open, account.CloseTime(open.AddDate(1, 0, 0))),
+		Balances: balance.Balances{
+			{Date: open, Amount: 10},
+			{Date: open.AddDate(0, 6, 0), Amount: -10, Currency: gbp},
+		},
+	}
+	data, err := json.Marshal(d)
+	common.FatalIfError(t, err, "Marshalling Document")
+
+	var version struct{ Version int }
+	common.FatalIfError(t, json.Unmarshal(data, &version), "Unmarshalling Document version")
+	assert.Equal(t, account.DocumentVersion, version.Version)
+
+	u, err := account.UnmarshalDocumentJSON(data)
+	common.FatalIfError(t, err, "Unmarshalling Document")
+	assert.True(t, d.Account.Equal(u.Account), "json: %s", data)
+	if assert.Len(t, u.Balances, 2) {
+		for i := range d.Balances {
+			assert.True(t, d.Balances[i].Equal(u.Balances[i]), "json: %s", data)
+		}
+	}
+}
+
+func TestUnmarshalDocumentJSON(t *testing.T) {
+	const accountJSON = `{"Name":"A","Opened":"2000-01-01T00:00:00Z","Closed":null,"Currency":"GBP"}`
+	for _, test := range []struct {
+		name string
+		data string
+		err  func(error) bool
+	}{
+		{
+			name: "valid",
+			data: `{"Version":1,"Account":` + accountJSON + `,"Balances":[{"Date":"2000-01-01T00:00:00Z","Amount":1}]}`,
+		},
+		{
+			name: "unsupported version",
+			data: `{"Version":2,"Account":` + accountJSON + `,"Balances":[]}`,
+			err: func(err error) bool {
+				return errors.Cause(err).Error() == account.ErrUnsupportedDocumentVersion
+			},
+		},
+		{
+			name: "balance before account opened",
+			data: `{"Version":1,"Account":` + accountJSON + `,"Balances":[{"Date":"1999-01-01T00:00:00Z","Amount":1}]}`,
+			err: func(err error) bool {
+				_, ok := errors.Cause(err).(balance.DateOutOfAccountTimeRange)
+				return ok
+			},
+		},
+		{
+			name: "balance of other currency",
+			data: `{"Version":1,"Account":` + accountJSON + `,"Balances":[{"Date":"2000-01-01T00:00:00Z","Amount":1,"Currency":"EUR"}]}`,
+			err: func(err error) bool {
+				_, ok := errors.Cause(err).(balance.CurrencyMismatch)
+				return ok
+			},
+		},
+		{
+			name: "invalid balance",
+			data: `{"Version":1,"Account":` + accountJSON + `,"Balances":[{"Date":"2000-01-01T00:00:00Z"}]}`,
+			err: func(err error) bool {
+				return err != nil
+			},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			d, err := account.UnmarshalDocumentJSON([]byte(test.data))
+			if test.err != nil {
+				assert.True(t, test.err(err), "unexpected error: %v", err)
+				assert.Nil(t, d)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Len(t, d.Balances, 1)
+		})
+	}
+}
@@ -34,5 +34,6 @@ func (e FieldError) Equal(other FieldError) bool {
 
 // Various error strings describing possible errors with potential new Account items.
 const (
-	EmptyNameError = "empty name"
+	EmptyNameError   = "empty name"
+	InvalidTypeError = "invalid type"
 )
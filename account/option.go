@@ -4,6 +4,7 @@ import (
 	"time"
 
 	gtime "github.com/glynternet/go-time"
+	"github.com/pkg/errors"
 )
 
 // Option is a function that takes a pointer to an Account returning an error.
@@ -17,3 +18,15 @@ func CloseTime(t time.Time) Option {
 		return gtime.End(t)(&a.timeRange)
 	}
 }
+
+// OfType returns an Option that will set the Type of an Account object.
+// An invalid Type will cause the Option to return an error.
+func OfType(t Type) Option {
+	return func(a *Account) error {
+		if !t.Valid() {
+			return errors.New(InvalidTypeError)
+		}
+		a.accountType = t
+		return nil
+	}
+}
@@ -20,4 +20,16 @@ func TestClosedTime(t *testing.T) {
 	closeB := closeA.Add(100 * time.Hour)
 	common.FatalIfError(t, account.CloseTime(closeB)(a), "Executing CloseTime Option")
 	assert.True(t, a.Closed().EqualTime(closeB))
-}
\ No newline at end of file
+}
+
+func TestOfType(t *testing.T) {
+	a, err := account.New("TEST_ACCOUNT", newTestCurrency(t, "EUR"), time.Now())
+	common.FatalIfError(t, err, "Creating Account")
+	assert.Equal(t, account.Unclassified, a.Type())
+
+	common.FatalIfError(t, account.OfType(account.Liability)(a), "Executing OfType Option")
+	assert.Equal(t, account.Liability, a.Type())
+
+	assert.Error(t, account.OfType(account.Type(99))(a))
+	assert.Equal(t, account.Liability, a.Type())
+}
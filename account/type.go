@@ -0,0 +1,51 @@
+package account
+
+import "github.com/pkg/errors"
+
+// Type is the classification of an Account within a chart of accounts.
+type Type int
+
+// The various Types that an Account can be.
+// An Account that has not been given a Type is Unclassified.
+const (
+	Unclassified Type = iota
+	Asset
+	Liability
+	Equity
+	Income
+	Expense
+)
+
+var typeNames = map[Type]string{
+	Unclassified: "unclassified",
+	Asset:        "asset",
+	Liability:    "liability",
+	Equity:       "equity",
+	Income:       "income",
+	Expense:      "expense",
+}
+
+// String returns the name of a Type.
+func (t Type) String() string {
+	if n, ok := typeNames[t]; ok {
+		return n
+	}
+	return "invalid"
+}
+
+// Valid returns true if the Type is one of the known Types.
+func (t Type) Valid() bool {
+	_, ok := typeNames[t]
+	return ok
+}
+
+// ParseType returns the Type with the given name.
+// ParseType returns an error if the name does not match any known Type.
+func ParseType(name string) (Type, error) {
+	for t, n := range typeNames {
+		if n == name {
+			return t, nil
+		}
+	}
+	return Unclassified, errors.Wrapf(errors.New(InvalidTypeError), "parsing %q", name)
+}
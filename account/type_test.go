@@ -0,0 +1,42 @@
+package account_test
+
+import (
+	"testing"
+
+	"github.com/glynternet/go-accounting/account"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestType_String(t *testing.T) {
+	for _, test := range []struct {
+		account.Type
+		name string
+	}{
+		{Type: account.Unclassified, name: "unclassified"},
+		{Type: account.Asset, name: "asset"},
+		{Type: account.Liability, name: "liability"},
+		{Type: account.Equity, name: "equity"},
+		{Type: account.Income, name: "income"},
+		{Type: account.Expense, name: "expense"},
+		{Type: account.Type(-1), name: "invalid"},
+	} {
+		assert.Equal(t, test.name, test.Type.String())
+	}
+}
+
+func TestParseType(t *testing.T) {
+	for _, ty := range []account.Type{
+		account.Unclassified,
+		account.Asset,
+		account.Liability,
+		account.Equity,
+		account.Income,
+		account.Expense,
+	} {
+		parsed, err := account.ParseType(ty.String())
+		assert.Nil(t, err)
+		assert.Equal(t, ty, parsed)
+	}
+	_, err := account.ParseType("invalid")
+	assert.Error(t, err)
+}
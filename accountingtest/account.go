@@ -20,3 +20,7 @@ func NewCurrencyCode(t *testing.T, code string) currency.Code {
 	common.FatalIfError(t, err, "Creating Currency Code")
 	return *c
 }
+
+func Date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,199 @@
+// Package amortization generates the schedule of payments that repay a loan.
+package amortization
+
+import (
+	"errors"
+	"math"
+	"time"
+
+	"github.com/glynternet/go-accounting/account"
+	"github.com/glynternet/go-accounting/balance"
+)
+
+// Various error messages describing possible errors when generating a Schedule.
+const (
+	ErrInvalidPrincipal   = "principal must be greater than zero"
+	ErrInvalidRate        = "rate must not be negative"
+	ErrInvalidTerm        = "term must be greater than zero"
+	ErrInvalidOverpayment = "overpayment must be greater than zero"
+)
+
+// Loan describes the terms of a loan.
+type Loan struct {
+	// Principal is the amount borrowed.
+	Principal int
+	// Annual is the annual rate of interest, where 5% is given as 0.05.
+	Annual float64
+	// Term is the number of payments over which the loan is repaid.
+	Term int
+	// Frequency is the Interval between payments.
+	Frequency balance.Interval
+}
+
+// Payment is a single scheduled repayment of a loan.
+// Amount is the total paid, made up of Interest and Principal. Principal
+// includes any Overpayment.
+type Payment struct {
+	Date        time.Time
+	Amount      int
+	Interest    int
+	Principal   int
+	Overpayment int
+	Remaining   int
+}
+
+// Schedule holds the Payments that repay a loan held by an Account, in Date
+// order.
+type Schedule struct {
+	Payments  []Payment
+	account   account.Account
+	principal int
+}
+
+var periodsPerYear = map[balance.Interval]float64{
+	balance.Day:     365,
+	balance.Week:    52,
+	balance.Month:   12,
+	balance.Quarter: 4,
+	balance.Year:    1,
+}
+
+// Option is a function that adds overpayments to a Schedule.
+type Option func(*overpayments) error
+
+type overpayments struct {
+	regular int
+	oneOff  []Payment
+}
+
+// Overpayment is an Option that pays an additional amount of principal with
+// the first payment at or after the given time.
+func Overpayment(t time.Time, amount int) Option {
+	return func(o *overpayments) error {
+		if amount <= 0 {
+			return errors.New(ErrInvalidOverpayment)
+		}
+		o.oneOff = append(o.oneOff, Payment{Date: t, Overpayment: amount})
+		return nil
+	}
+}
+
+// RegularOverpayment is an Option that pays an additional amount of principal
+// with every payment.
+func RegularOverpayment(amount int) Option {
+	return func(o *overpayments) error {
+		if amount <= 0 {
+			return errors.New(ErrInvalidOverpayment)
+		}
+		o.regular += amount
+		return nil
+	}
+}
+
+// New generates the Schedule that repays a Loan held by an Account.
+// The Loan is taken out when the Account opens and is repaid by equal
+// payments, one Frequency apart, over the Term of the Loan. Interest for each
+// payment is charged on the remaining principal at the annual rate divided by
+// the number of payments in a year, rounded half away from zero. The final
+// payment repays whatever principal remains.
+// Overpayments reduce the remaining principal without changing the amount of
+// each regular payment, so they shorten the Schedule.
+// Payments that would be after the Account is closed are not included.
+func New(a account.Account, l Loan, os ...Option) (Schedule, error) {
+	switch {
+	case l.Principal <= 0:
+		return Schedule{}, errors.New(ErrInvalidPrincipal)
+	case l.Annual < 0:
+		return Schedule{}, errors.New(ErrInvalidRate)
+	case l.Term <= 0:
+		return Schedule{}, errors.New(ErrInvalidTerm)
+	case !l.Frequency.Valid():
+		return Schedule{}, errors.New(balance.ErrInvalidInterval)
+	}
+	var o overpayments
+	for _, opt := range os {
+		if err := opt(&o); err != nil {
+			return Schedule{}, err
+		}
+	}
+
+	rate := l.Annual / periodsPerYear[l.Frequency]
+	regular := regularPayment(l.Principal, rate, l.Term)
+	s := Schedule{account: a, principal: l.Principal}
+	remaining := l.Principal
+	for n := 1; remaining > 0; n++ {
+		date := l.Frequency.Add(a.Opened(), n)
+		if a.Closed().Valid && date.After(a.Closed().Time) {
+			break
+		}
+		p := Payment{
+			Date:        date,
+			Interest:    int(math.Round(float64(remaining) * rate)),
+			Overpayment: o.regular + o.due(date),
+		}
+		p.Principal = regular - p.Interest + p.Overpayment
+		if n == l.Term || p.Principal > remaining {
+			p.Principal = remaining
+		}
+		if p.Overpayment > p.Principal {
+			p.Overpayment = p.Principal
+		}
+		p.Amount = p.Interest + p.Principal
+		remaining -= p.Principal
+		p.Remaining = remaining
+		s.Payments = append(s.Payments, p)
+	}
+	return s, nil
+}
+
+// due returns the total of the one-off overpayments that are due at or before
+// the given time and have not yet been paid, marking them as paid.
+func (o *overpayments) due(t time.Time) int {
+	var total int
+	var pending []Payment
+	for _, p := range o.oneOff {
+		if p.Date.After(t) {
+			pending = append(pending, p)
+			continue
+		}
+		total += p.Overpayment
+	}
+	o.oneOff = pending
+	return total
+}
+
+func regularPayment(principal int, rate float64, term int) int {
+	if rate == 0 {
+		return int(math.Ceil(float64(principal) / float64(term)))
+	}
+	return int(math.Round(float64(principal) * rate / (1 - math.Pow(1+rate, -float64(term)))))
+}
+
+// TotalInterest returns the total Interest paid over the Schedule.
+func (s Schedule) TotalInterest() int {
+	var total int
+	for _, p := range s.Payments {
+		total += p.Interest
+	}
+	return total
+}
+
+// Balances returns the expected Balances of the Account that holds the loan,
+// in the currency of the Account. The amount owed is given as a negative
+// Balance, from the principal when the Account opens to the Remaining
+// principal after each Payment.
+func (s Schedule) Balances() balance.Balances {
+	bs := balance.Balances{{
+		Date:     s.account.Opened(),
+		Amount:   -s.principal,
+		Currency: s.account.CurrencyCode(),
+	}}
+	for _, p := range s.Payments {
+		bs = append(bs, balance.Balance{
+			Date:     p.Date,
+			Amount:   -p.Remaining,
+			Currency: s.account.CurrencyCode(),
+		})
+	}
+	return bs
+}
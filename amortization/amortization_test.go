@@ -0,0 +1,125 @@
+package amortization_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/glynternet/go-accounting/account"
+	"github.com/glynternet/go-accounting/accountingtest"
+	"github.com/glynternet/go-accounting/amortization"
+	"github.com/glynternet/go-accounting/balance"
+	"github.com/stretchr/testify/assert"
+)
+
+func newLoanAccount(t *testing.T, os ...account.Option) account.Account {
+	os = append(os, account.OfType(account.Liability))
+	return *accountingtest.NewAccount(t, "Loan", accountingtest.NewCurrencyCode(t, "GBP"), accountingtest.Date(2020, 1, 15), os...)
+}
+
+func TestNew(t *testing.T) {
+	a := newLoanAccount(t)
+	s, err := amortization.New(a, amortization.Loan{
+		Principal: 120000,
+		Annual:    0.12,
+		Term:      12,
+		Frequency: balance.Month,
+	})
+	assert.Nil(t, err)
+	assert.Len(t, s.Payments, 12)
+	assert.Equal(t, amortization.Payment{
+		Date:      accountingtest.Date(2020, 2, 15),
+		Amount:    10662,
+		Interest:  1200,
+		Principal: 9462,
+		Remaining: 110538,
+	}, s.Payments[0])
+	last := s.Payments[11]
+	assert.Equal(t, accountingtest.Date(2021, 1, 15), last.Date)
+	assert.Equal(t, 0, last.Remaining)
+
+	var principal int
+	for _, p := range s.Payments {
+		assert.Equal(t, p.Amount, p.Interest+p.Principal)
+		principal += p.Principal
+	}
+	assert.Equal(t, 120000, principal)
+	assert.InDelta(t, 12*10662-120000, s.TotalInterest(), 12, "final payment should be within rounding of the regular payment")
+
+	bs := s.Balances()
+	assert.Len(t, bs, 13)
+	assert.Equal(t, balance.Balance{Date: accountingtest.Date(2020, 1, 15), Amount: -120000, Currency: a.CurrencyCode()}, bs[0])
+	assert.Equal(t, balance.Balance{Date: accountingtest.Date(2020, 2, 15), Amount: -110538, Currency: a.CurrencyCode()}, bs[1])
+	for _, b := range bs {
+		assert.Nil(t, a.ValidateBalance(b))
+	}
+}
+
+func TestNew_ZeroRate(t *testing.T) {
+	s, err := amortization.New(newLoanAccount(t), amortization.Loan{
+		Principal: 1000,
+		Term:      3,
+		Frequency: balance.Quarter,
+	})
+	assert.Nil(t, err)
+	var amounts []int
+	for _, p := range s.Payments {
+		amounts = append(amounts, p.Amount)
+	}
+	assert.Equal(t, []int{334, 334, 332}, amounts)
+	assert.Equal(t, 0, s.TotalInterest())
+}
+
+func TestNew_Overpayments(t *testing.T) {
+	loan := amortization.Loan{Principal: 120000, Annual: 0.12, Term: 12, Frequency: balance.Month}
+	a := newLoanAccount(t)
+	regular, err := amortization.New(a, loan)
+	assert.Nil(t, err)
+
+	s, err := amortization.New(a, loan, amortization.Overpayment(accountingtest.Date(2020, 3, 1), 50000))
+	assert.Nil(t, err)
+	assert.Equal(t, regular.Payments[0], s.Payments[0])
+	assert.Equal(t, accountingtest.Date(2020, 3, 15), s.Payments[1].Date)
+	assert.Equal(t, 50000, s.Payments[1].Overpayment)
+	assert.Equal(t, s.Payments[1].Amount, regular.Payments[1].Amount+50000)
+	assert.True(t, len(s.Payments) < len(regular.Payments))
+	assert.True(t, s.TotalInterest() < regular.TotalInterest())
+	assert.Equal(t, 0, s.Payments[len(s.Payments)-1].Remaining)
+
+	s, err = amortization.New(a, loan, amortization.RegularOverpayment(100000))
+	assert.Nil(t, err)
+	assert.Len(t, s.Payments, 2)
+	assert.Equal(t, 100000, s.Payments[0].Overpayment)
+	assert.Equal(t, 0, s.Payments[1].Remaining)
+	assert.Equal(t, s.Payments[1].Principal, s.Payments[1].Overpayment)
+}
+
+func TestNew_ClosedAccount(t *testing.T) {
+	a := newLoanAccount(t, account.CloseTime(accountingtest.Date(2020, 4, 15)))
+	s, err := amortization.New(a, amortization.Loan{Principal: 120000, Annual: 0.12, Term: 12, Frequency: balance.Month})
+	assert.Nil(t, err)
+	assert.Len(t, s.Payments, 3)
+	assert.Equal(t, accountingtest.Date(2020, 4, 15), s.Payments[2].Date)
+	for _, b := range s.Balances() {
+		assert.Nil(t, a.ValidateBalance(b))
+	}
+}
+
+func TestNew_Errors(t *testing.T) {
+	valid := amortization.Loan{Principal: 1, Term: 1, Frequency: balance.Month}
+	for _, test := range []struct {
+		name string
+		amortization.Loan
+		os  []amortization.Option
+		err error
+	}{
+		{name: "principal", Loan: amortization.Loan{Term: 1, Frequency: balance.Month}, err: errors.New(amortization.ErrInvalidPrincipal)},
+		{name: "rate", Loan: amortization.Loan{Principal: 1, Annual: -0.1, Term: 1, Frequency: balance.Month}, err: errors.New(amortization.ErrInvalidRate)},
+		{name: "term", Loan: amortization.Loan{Principal: 1, Frequency: balance.Month}, err: errors.New(amortization.ErrInvalidTerm)},
+		{name: "frequency", Loan: amortization.Loan{Principal: 1, Term: 1}, err: errors.New(balance.ErrInvalidInterval)},
+		{name: "overpayment", Loan: valid, os: []amortization.Option{amortization.Overpayment(accountingtest.Date(2020, 1, 1), 0)}, err: errors.New(amortization.ErrInvalidOverpayment)},
+		{name: "regular overpayment", Loan: valid, os: []amortization.Option{amortization.RegularOverpayment(-1)}, err: errors.New(amortization.ErrInvalidOverpayment)},
+	} {
+		_, err := amortization.New(newLoanAccount(t), test.Loan, test.os...)
+		assert.Equal(t, test.err, err, test.name)
+	}
+}
@@ -3,12 +3,16 @@ package balance
 import (
 	"errors"
 	"time"
+
+	"github.com/glynternet/go-accounting/internal/timeseries"
+	"github.com/glynternet/go-money/currency"
 )
 
 // ErrEmptyBalancesMessage is the error message used when a Balances object contains no Balance items.
 const (
 	ErrEmptyBalancesMessage = "empty Balances"
 	ErrNoBalances           = "no Balances"
+	ErrMixedCurrencies      = "Balances contain multiple currencies"
 )
 
 // New creates a new Balance
@@ -25,25 +29,50 @@ func New(date time.Time, options ...Option) (b *Balance, err error) {
 }
 
 // Balance holds the logic for a Balance item.
+// A Balance with a zero-value Currency has no currency attached to it.
 type Balance struct {
-	Date   time.Time
-	Amount int
+	Date     time.Time
+	Amount   int
+	Currency currency.Code
 }
 
 // Equal returns true if two Balance objects are logically equal
 func (b Balance) Equal(ob Balance) bool {
-	return b.Amount == ob.Amount && b.Date.Equal(ob.Date)
+	return b.Amount == ob.Amount && b.Date.Equal(ob.Date) && b.Currency == ob.Currency
+}
+
+// HasCurrency returns true if the Balance has a currency attached to it.
+func (b Balance) HasCurrency() bool {
+	var none currency.Code
+	return b.Currency != none
 }
 
 //Balances holds multiple Balance items.
 type Balances []Balance
 
 // Sum returns the value of all of the balances summed together.
-func (bs Balances) Sum() (s int) {
+// If the Balances contain more than one currency, an ErrMixedCurrencies error
+// is returned, as the amounts cannot be meaningfully summed. Balances without
+// a currency are considered to be a currency of their own.
+func (bs Balances) Sum() (int, error) {
+	sums := bs.SumByCurrency()
+	if len(sums) > 1 {
+		return 0, errors.New(ErrMixedCurrencies)
+	}
+	for _, s := range sums {
+		return s, nil
+	}
+	return 0, nil
+}
+
+// SumByCurrency returns the value of all of the balances of each currency
+// summed together, keyed by currency.Code.
+func (bs Balances) SumByCurrency() map[currency.Code]int {
+	sums := make(map[currency.Code]int)
 	for _, b := range bs {
-		s += b.Amount
+		sums[b.Currency] += b.Amount
 	}
-	return
+	return sums
 }
 
 // Earliest returns the Balance with the earliest Date contained in a Balances set.
@@ -82,20 +111,9 @@ func (bs Balances) Latest() (Balance, error) {
 // If multiple Balances have the same date that is the latest, the Balance that
 // was encountered last will be returned.
 func (bs Balances) AtTime(t time.Time) (Balance, error) {
-	var at *Balance
-	for i := range bs {
-		if bs[i].Date.After(t) {
-			continue
-		}
-		if at == nil {
-			at = &bs[i]
-		}
-		if !at.Date.After(bs[i].Date) {
-			at = &bs[i]
-		}
-	}
-	if at == nil {
+	i := timeseries.LatestAt(len(bs), func(i int) time.Time { return bs[i].Date }, t)
+	if i < 0 {
 		return Balance{}, errors.New(ErrNoBalances)
 	}
-	return *at, nil
+	return bs[i], nil
 }
@@ -9,6 +9,7 @@ import (
 
 	"github.com/glynternet/go-accounting/balance"
 	"github.com/glynternet/go-money/common"
+	"github.com/glynternet/go-money/currency"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -42,6 +43,10 @@ func TestBalance_Equal(t *testing.T) {
 			name: "different time",
 			b:    newTestBalance(t, year+1, balance.Amount(123)),
 		},
+		{
+			name: "different currency",
+			b:    newTestBalance(t, year, balance.Amount(123), balance.CurrencyCode(newTestCurrency(t, "GBP"))),
+		},
 	} {
 		t.Run(test.name, func(t *testing.T) {
 			assert.Equal(t, test.equal, a.Equal(test.b))
@@ -298,10 +303,50 @@ func TestBalances_Sum(t *testing.T) {
 			common.FatalIfErrorf(t, err, "[%d] creating balance for testing", i)
 			bs = append(bs, *b)
 		}
-		assert.Equal(t, testSet.sum, bs.Sum())
+		sum, err := bs.Sum()
+		assert.Nil(t, err)
+		assert.Equal(t, testSet.sum, sum)
+	}
+
+	gbp := balance.CurrencyCode(newTestCurrency(t, "GBP"))
+	eur := balance.CurrencyCode(newTestCurrency(t, "EUR"))
+	sum, err := balance.Balances{
+		newTestBalance(t, 2000, balance.Amount(1), gbp),
+		newTestBalance(t, 2001, balance.Amount(2), gbp),
+	}.Sum()
+	assert.Nil(t, err)
+	assert.Equal(t, 3, sum)
+
+	for _, bs := range []balance.Balances{
+		{
+			newTestBalance(t, 2000, balance.Amount(1), gbp),
+			newTestBalance(t, 2001, balance.Amount(2), eur),
+		},
+		{
+			newTestBalance(t, 2000, balance.Amount(1), gbp),
+			newTestBalance(t, 2001, balance.Amount(2)),
+		},
+	} {
+		sum, err = bs.Sum()
+		assert.Equal(t, errors.New(balance.ErrMixedCurrencies), err)
+		assert.Equal(t, 0, sum)
 	}
 }
 
+func TestBalances_SumByCurrency(t *testing.T) {
+	gbp := newTestCurrency(t, "GBP")
+	eur := newTestCurrency(t, "EUR")
+	assert.Empty(t, balance.Balances{}.SumByCurrency())
+	assert.Equal(t, map[currency.Code]int{
+		gbp: 3,
+		eur: -5,
+	}, balance.Balances{
+		newTestBalance(t, 2000, balance.Amount(1), balance.CurrencyCode(gbp)),
+		newTestBalance(t, 2000, balance.Amount(-5), balance.CurrencyCode(eur)),
+		newTestBalance(t, 2001, balance.Amount(2), balance.CurrencyCode(gbp)),
+	}.SumByCurrency())
+}
+
 func TestBalance_MarshalJSON(t *testing.T) {
 	a, err := balance.New(time.Now(), balance.Amount(921368))
 	common.FatalIfError(t, err, "Creating balance")
@@ -339,6 +384,12 @@ func newTestBalance(t *testing.T, year int, options ...balance.Option) balance.B
 	return *b
 }
 
+func newTestCurrency(t *testing.T, code string) currency.Code {
+	c, err := currency.NewCode(code)
+	common.FatalIfError(t, err, "Creating Currency Code")
+	return *c
+}
+
 func newTestDate(year int) time.Time {
 	return time.Date(year, 1, 1, 1, 1, 1, 1, time.UTC)
 }
@@ -1,8 +1,10 @@
 package balance
 
 import (
+	"fmt"
 	"time"
 
+	"github.com/glynternet/go-money/currency"
 	gohtime "github.com/glynternet/go-time"
 )
 
@@ -19,3 +21,14 @@ type DateOutOfAccountTimeRange struct {
 func (e DateOutOfAccountTimeRange) Error() string {
 	return balanceDateOutOfRangeMessage
 }
+
+// CurrencyMismatch is a type returned when the Currency of a Balance is not the currency of the Account that holds it.
+type CurrencyMismatch struct {
+	BalanceCurrency currency.Code
+	AccountCurrency currency.Code
+}
+
+// Error ensures that CurrencyMismatch adheres to the error interface.
+func (e CurrencyMismatch) Error() string {
+	return fmt.Sprintf("Balance currency %s does not match Account currency %s.", e.BalanceCurrency, e.AccountCurrency)
+}
@@ -3,9 +3,19 @@ package balance
 import (
 	"testing"
 
+	"github.com/glynternet/go-money/currency"
 	"github.com/stretchr/testify/assert"
 )
 
 func TestDateOutOfAccountTimeRange_Error(t *testing.T) {
 	assert.Equal(t, DateOutOfAccountTimeRange{}.Error(), balanceDateOutOfRangeMessage)
 }
+
+func TestCurrencyMismatch_Error(t *testing.T) {
+	gbp, err := currency.NewCode("GBP")
+	assert.Nil(t, err)
+	eur, err := currency.NewCode("EUR")
+	assert.Nil(t, err)
+	e := CurrencyMismatch{BalanceCurrency: *gbp, AccountCurrency: *eur}
+	assert.Equal(t, "Balance currency GBP does not match Account currency EUR.", e.Error())
+}
@@ -0,0 +1,109 @@
+package balance
+
+import (
+	"errors"
+	"math"
+	"time"
+)
+
+// Various error strings describing possible errors when interpolating Balances.
+const (
+	ErrInvalidInterpolation = "invalid Interpolation"
+	ErrOutOfRange           = "time is outside of the range of the Balances"
+)
+
+// Interpolation is a strategy for estimating the value of Balances at a time
+// between two of its Balance items.
+type Interpolation int
+
+// The various Interpolations that can be used with Balances.Interpolate.
+const (
+	// Step gives the Amount of the latest Balance at or before the time, the
+	// same as Balances.AtTime.
+	Step Interpolation = iota + 1
+	// Linear gives an Amount on the straight line between the Balances either
+	// side of the time, rounded to the nearest whole amount.
+	Linear
+	// Next gives the Amount of the earliest Balance at or after the time.
+	Next
+)
+
+var interpolationNames = map[Interpolation]string{
+	Step:   "step",
+	Linear: "linear",
+	Next:   "next",
+}
+
+// String returns the name of an Interpolation.
+func (i Interpolation) String() string {
+	if n, ok := interpolationNames[i]; ok {
+		return n
+	}
+	return "invalid"
+}
+
+// Valid returns true if the Interpolation is one of the known Interpolations.
+func (i Interpolation) Valid() bool {
+	_, ok := interpolationNames[i]
+	return ok
+}
+
+// Interpolate returns a Balance with the given Date, with an Amount estimated
+// from the Balances using the given Interpolation.
+// The time must be within the earliest and latest Dates of the Balances,
+// inclusive, otherwise an ErrOutOfRange error is returned. When the time is
+// the Date of a Balance, every Interpolation gives that Balance's Amount and,
+// as with AtTime, the Balance encountered last is used when there are
+// several with the same Date.
+// Linear Interpolation returns an ErrMixedCurrencies error when the Balances
+// either side of the time have different currencies.
+func (bs Balances) Interpolate(t time.Time, i Interpolation) (Balance, error) {
+	if !i.Valid() {
+		return Balance{}, errors.New(ErrInvalidInterpolation)
+	}
+	if len(bs) == 0 {
+		return Balance{}, errors.New(ErrEmptyBalancesMessage)
+	}
+	prev, err := bs.AtTime(t)
+	if err != nil {
+		return Balance{}, errors.New(ErrOutOfRange)
+	}
+	if prev.Date.Equal(t) {
+		return Balance{Date: t, Amount: prev.Amount, Currency: prev.Currency}, nil
+	}
+	nextDate, ok := bs.nextDate(t)
+	if !ok {
+		return Balance{}, errors.New(ErrOutOfRange)
+	}
+	next, err := bs.AtTime(nextDate)
+	if err != nil {
+		return Balance{}, err
+	}
+	switch i {
+	case Step:
+		return Balance{Date: t, Amount: prev.Amount, Currency: prev.Currency}, nil
+	case Next:
+		return Balance{Date: t, Amount: next.Amount, Currency: next.Currency}, nil
+	}
+	if prev.Currency != next.Currency {
+		return Balance{}, errors.New(ErrMixedCurrencies)
+	}
+	fraction := float64(t.Sub(prev.Date)) / float64(next.Date.Sub(prev.Date))
+	amount := int(math.Round(float64(prev.Amount) + fraction*float64(next.Amount-prev.Amount)))
+	return Balance{Date: t, Amount: amount, Currency: prev.Currency}, nil
+}
+
+// nextDate returns the earliest Date of the Balances that is after t.
+func (bs Balances) nextDate(t time.Time) (time.Time, bool) {
+	var next time.Time
+	var found bool
+	for _, b := range bs {
+		if !b.Date.After(t) {
+			continue
+		}
+		if !found || b.Date.Before(next) {
+			next, found = b.Date, true
+		}
+	}
+	return next, found
+}
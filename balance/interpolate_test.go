@@ -0,0 +1,73 @@
+package balance_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/glynternet/go-accounting/accountingtest"
+	"github.com/glynternet/go-accounting/balance"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInterpolation_Valid(t *testing.T) {
+	for _, i := range []balance.Interpolation{balance.Step, balance.Linear, balance.Next} {
+		assert.True(t, i.Valid(), i.String())
+	}
+	assert.False(t, balance.Interpolation(0).Valid())
+	assert.Equal(t, "invalid", balance.Interpolation(0).String())
+}
+
+func TestBalances_Interpolate(t *testing.T) {
+	gbp := newTestCurrency(t, "GBP")
+	bs := balance.Balances{
+		{Date: accountingtest.Date(2020, 1, 11), Amount: 200, Currency: gbp},
+		{Date: accountingtest.Date(2020, 1, 1), Amount: 100, Currency: gbp},
+		{Date: accountingtest.Date(2020, 1, 21), Amount: -101, Currency: gbp},
+		{Date: accountingtest.Date(2020, 1, 11), Amount: 300, Currency: gbp},
+	}
+	for _, test := range []struct {
+		name string
+		time.Time
+		balance.Interpolation
+		amount int
+		err    error
+	}{
+		{name: "step between", Time: accountingtest.Date(2020, 1, 6), Interpolation: balance.Step, amount: 100},
+		{name: "linear between", Time: accountingtest.Date(2020, 1, 6), Interpolation: balance.Linear, amount: 200},
+		{name: "next between", Time: accountingtest.Date(2020, 1, 6), Interpolation: balance.Next, amount: 300},
+		{name: "linear rounds", Time: accountingtest.Date(2020, 1, 12), Interpolation: balance.Linear, amount: 260},
+		{name: "linear rounds half away from zero", Time: accountingtest.Date(2020, 1, 16), Interpolation: balance.Linear, amount: 100},
+		{name: "step at earliest", Time: accountingtest.Date(2020, 1, 1), Interpolation: balance.Step, amount: 100},
+		{name: "next at duplicated date", Time: accountingtest.Date(2020, 1, 11), Interpolation: balance.Next, amount: 300},
+		{name: "linear at latest", Time: accountingtest.Date(2020, 1, 21), Interpolation: balance.Linear, amount: -101},
+		{name: "before earliest", Time: accountingtest.Date(2019, 12, 31), Interpolation: balance.Linear, err: errors.New(balance.ErrOutOfRange)},
+		{name: "after latest", Time: accountingtest.Date(2020, 1, 22), Interpolation: balance.Step, err: errors.New(balance.ErrOutOfRange)},
+		{name: "invalid", Time: accountingtest.Date(2020, 1, 6), Interpolation: balance.Interpolation(0), err: errors.New(balance.ErrInvalidInterpolation)},
+	} {
+		b, err := bs.Interpolate(test.Time, test.Interpolation)
+		assert.Equal(t, test.err, err, test.name)
+		if test.err != nil {
+			continue
+		}
+		assert.Equal(t, balance.Balance{Date: test.Time, Amount: test.amount, Currency: gbp}, b, test.name)
+	}
+}
+
+func TestBalances_Interpolate_Empty(t *testing.T) {
+	_, err := balance.Balances{}.Interpolate(accountingtest.Date(2020, 1, 1), balance.Step)
+	assert.Equal(t, errors.New(balance.ErrEmptyBalancesMessage), err)
+}
+
+func TestBalances_Interpolate_MixedCurrencies(t *testing.T) {
+	bs := balance.Balances{
+		{Date: accountingtest.Date(2020, 1, 1), Amount: 100, Currency: newTestCurrency(t, "GBP")},
+		{Date: accountingtest.Date(2020, 1, 3), Amount: 300, Currency: newTestCurrency(t, "EUR")},
+	}
+	_, err := bs.Interpolate(accountingtest.Date(2020, 1, 2), balance.Linear)
+	assert.Equal(t, errors.New(balance.ErrMixedCurrencies), err)
+
+	b, err := bs.Interpolate(accountingtest.Date(2020, 1, 2), balance.Next)
+	assert.Nil(t, err)
+	assert.Equal(t, 300, b.Amount)
+}
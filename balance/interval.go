@@ -0,0 +1,89 @@
+package balance
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrInvalidInterval is the error message used when an Interval is not one of the known Intervals.
+const ErrInvalidInterval = "invalid Interval"
+
+// Interval is a regular period of calendar time.
+type Interval int
+
+// The various Intervals that can be used to step through time.
+const (
+	Day Interval = iota + 1
+	Week
+	Month
+	Quarter
+	Year
+)
+
+var intervalNames = map[Interval]string{
+	Day:     "day",
+	Week:    "week",
+	Month:   "month",
+	Quarter: "quarter",
+	Year:    "year",
+}
+
+// String returns the name of an Interval.
+func (i Interval) String() string {
+	if n, ok := intervalNames[i]; ok {
+		return n
+	}
+	return "invalid"
+}
+
+// Valid returns true if the Interval is one of the known Intervals.
+func (i Interval) Valid() bool {
+	_, ok := intervalNames[i]
+	return ok
+}
+
+// Add returns the time that is n Intervals after t.
+// When adding months, quarters or years, the day of the month is clamped to
+// the last day of the resulting month, so that adding one Month to January
+// 31st gives the last day of February rather than a day in March.
+func (i Interval) Add(t time.Time, n int) time.Time {
+	switch i {
+	case Day:
+		return t.AddDate(0, 0, n)
+	case Week:
+		return t.AddDate(0, 0, 7*n)
+	case Month:
+		return addMonths(t, n)
+	case Quarter:
+		return addMonths(t, 3*n)
+	case Year:
+		return addMonths(t, 12*n)
+	}
+	return t
+}
+
+func addMonths(t time.Time, n int) time.Time {
+	first := time.Date(t.Year(), t.Month()+time.Month(n), 1, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+	day := t.Day()
+	if last := first.AddDate(0, 1, -1).Day(); day > last {
+		day = last
+	}
+	return first.AddDate(0, 0, day-1)
+}
+
+// Times returns the times from start, stepping by the Interval, up to and
+// including end. Each time is calculated from start, rather than from the
+// previous time, so that clamping of the day of the month does not accumulate.
+func (i Interval) Times(start, end time.Time) ([]time.Time, error) {
+	if !i.Valid() {
+		return nil, errors.New(ErrInvalidInterval)
+	}
+	var ts []time.Time
+	for n := 0; ; n++ {
+		t := i.Add(start, n)
+		if t.After(end) {
+			return ts, nil
+		}
+		ts = append(ts, t)
+	}
+}
@@ -0,0 +1,61 @@
+package balance_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/glynternet/go-accounting/balance"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInterval_Add(t *testing.T) {
+	jan31 := time.Date(2020, 1, 31, 12, 0, 0, 0, time.UTC)
+	for _, test := range []struct {
+		balance.Interval
+		n        int
+		expected time.Time
+	}{
+		{Interval: balance.Day, n: 1, expected: time.Date(2020, 2, 1, 12, 0, 0, 0, time.UTC)},
+		{Interval: balance.Week, n: 2, expected: time.Date(2020, 2, 14, 12, 0, 0, 0, time.UTC)},
+		{Interval: balance.Month, n: 1, expected: time.Date(2020, 2, 29, 12, 0, 0, 0, time.UTC)},
+		{Interval: balance.Month, n: 2, expected: time.Date(2020, 3, 31, 12, 0, 0, 0, time.UTC)},
+		{Interval: balance.Month, n: -2, expected: time.Date(2019, 11, 30, 12, 0, 0, 0, time.UTC)},
+		{Interval: balance.Quarter, n: 1, expected: time.Date(2020, 4, 30, 12, 0, 0, 0, time.UTC)},
+		{Interval: balance.Year, n: 1, expected: time.Date(2021, 1, 31, 12, 0, 0, 0, time.UTC)},
+		{Interval: balance.Interval(0), n: 1, expected: jan31},
+	} {
+		assert.Equal(t, test.expected, test.Interval.Add(jan31, test.n), "%s %d", test.Interval, test.n)
+	}
+	assert.Equal(t,
+		time.Date(2021, 2, 28, 0, 0, 0, 0, time.UTC),
+		balance.Year.Add(time.Date(2020, 2, 29, 0, 0, 0, 0, time.UTC), 1),
+	)
+}
+
+func TestInterval_Valid(t *testing.T) {
+	for _, i := range []balance.Interval{balance.Day, balance.Week, balance.Month, balance.Quarter, balance.Year} {
+		assert.True(t, i.Valid(), i.String())
+	}
+	assert.False(t, balance.Interval(0).Valid())
+	assert.Equal(t, "invalid", balance.Interval(0).String())
+}
+
+func TestInterval_Times(t *testing.T) {
+	start := time.Date(2020, 1, 31, 0, 0, 0, 0, time.UTC)
+	ts, err := balance.Month.Times(start, time.Date(2020, 4, 30, 0, 0, 0, 0, time.UTC))
+	assert.Nil(t, err)
+	assert.Equal(t, []time.Time{
+		start,
+		time.Date(2020, 2, 29, 0, 0, 0, 0, time.UTC),
+		time.Date(2020, 3, 31, 0, 0, 0, 0, time.UTC),
+		time.Date(2020, 4, 30, 0, 0, 0, 0, time.UTC),
+	}, ts)
+
+	ts, err = balance.Day.Times(start, start.Add(-time.Nanosecond))
+	assert.Nil(t, err)
+	assert.Empty(t, ts)
+
+	_, err = balance.Interval(99).Times(start, start)
+	assert.Equal(t, errors.New(balance.ErrInvalidInterval), err)
+}
@@ -0,0 +1,91 @@
+package balance
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/glynternet/go-money/currency"
+	"github.com/pkg/errors"
+)
+
+// Various error messages describing possible errors when unmarshalling a Balance.
+const (
+	ErrMissingDate   = "missing Date"
+	ErrMissingAmount = "missing Amount"
+)
+
+// MarshalJSON marshals a Balance into a json blob, returning the blob with any errors that occur during the marshalling.
+//
+// A Balance is marshalled as an object with the following fields:
+//
+//	Date     string, RFC 3339 with nanoseconds, required
+//	Amount   number, integer amount in minor units, required
+//	Currency string, ISO 4217 currency code, omitted when the Balance has no currency
+//
+// Balances are marshalled as an array of Balance objects.
+func (b Balance) MarshalJSON() ([]byte, error) {
+	aux := struct {
+		Date     time.Time
+		Amount   int
+		Currency string `json:",omitempty"`
+	}{
+		Date:   b.Date,
+		Amount: b.Amount,
+	}
+	if b.HasCurrency() {
+		aux.Currency = fmt.Sprint(b.Currency)
+	}
+	return json.Marshal(aux)
+}
+
+// UnmarshalJSON attempts to unmarshal a json blob into a Balance object,
+// returning any errors that occur during the unmarshalling.
+// UnmarshalJSON returns an error if the Date or Amount fields are missing, or
+// if the Currency field is present but is not a valid currency code.
+func (b *Balance) UnmarshalJSON(data []byte) error {
+	var aux struct {
+		Date     *time.Time
+		Amount   *int
+		Currency string
+	}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return errors.Wrap(err, "unmarshalling data to auxilliary")
+	}
+	if aux.Date == nil {
+		return errors.New(ErrMissingDate)
+	}
+	if aux.Amount == nil {
+		return errors.New(ErrMissingAmount)
+	}
+	var c currency.Code
+	if aux.Currency != "" {
+		code, err := currency.NewCode(aux.Currency)
+		if err != nil {
+			return errors.Wrapf(err, "creating new currency for %s", aux.Currency)
+		}
+		c = *code
+	}
+	*b = Balance{Date: *aux.Date, Amount: *aux.Amount, Currency: c}
+	return nil
+}
+
+// UnmarshalJSON unmarshals json data into a Balance
+func UnmarshalJSON(data []byte) (*Balance, error) {
+	var b Balance
+	err := json.Unmarshal(data, &b)
+	if err != nil {
+		return nil, err
+	}
+	return &b, nil
+}
+
+// UnmarshalBalancesJSON unmarshals json data into a Balances
+func UnmarshalBalancesJSON(data []byte) (Balances, error) {
+	var bs Balances
+	err := json.Unmarshal(data, &bs)
+	if err != nil {
+		return nil, err
+	}
+	return bs, nil
+}
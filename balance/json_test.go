@@ -0,0 +1,107 @@
+package balance_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/glynternet/go-accounting/balance"
+	"github.com/glynternet/go-money/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBalance_MarshalJSON_Schema(t *testing.T) {
+	date := time.Date(2000, 1, 2, 3, 4, 5, 6, time.UTC)
+	for _, test := range []struct {
+		name string
+		balance.Balance
+		expected string
+	}{
+		{
+			name:     "without currency",
+			Balance:  balance.Balance{Date: date, Amount: -12},
+			expected: `{"Date":"2000-01-02T03:04:05.000000006Z","Amount":-12}`,
+		},
+		{
+			name:     "with currency",
+			Balance:  balance.Balance{Date: date, Amount: 34, Currency: newTestCurrency(t, "GBP")},
+			expected: `{"Date":"2000-01-02T03:04:05.000000006Z","Amount":34,"Currency":"GBP"}`,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			data, err := json.Marshal(test.Balance)
+			common.FatalIfError(t, err, "Marshalling JSON")
+			assert.JSONEq(t, test.expected, string(data))
+
+			b, err := balance.UnmarshalJSON(data)
+			common.FatalIfError(t, err, "Unmarshalling JSON")
+			assert.True(t, test.Balance.Equal(*b), "json: %s", data)
+		})
+	}
+}
+
+func TestUnmarshalJSON(t *testing.T) {
+	for _, test := range []struct {
+		name     string
+		data     string
+		expected *balance.Balance
+		err      error
+	}{
+		{
+			name:     "valid",
+			data:     `{"Date":"2000-01-01T00:00:00Z","Amount":0}`,
+			expected: &balance.Balance{Date: time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)},
+		},
+		{
+			name: "missing date",
+			data: `{"Amount":1}`,
+			err:  errors.New(balance.ErrMissingDate),
+		},
+		{
+			name: "missing amount",
+			data: `{"Date":"2000-01-01T00:00:00Z"}`,
+			err:  errors.New(balance.ErrMissingAmount),
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			b, err := balance.UnmarshalJSON([]byte(test.data))
+			if test.err != nil {
+				assert.EqualError(t, err, test.err.Error())
+				assert.Nil(t, b)
+				return
+			}
+			assert.Nil(t, err)
+			assert.True(t, test.expected.Equal(*b))
+		})
+	}
+
+	for _, invalid := range []string{
+		`{`,
+		`{"Date":"yesterday","Amount":1}`,
+		`{"Date":"2000-01-01T00:00:00Z","Amount":1.5}`,
+		`{"Date":"2000-01-01T00:00:00Z","Amount":1,"Currency":"pounds"}`,
+	} {
+		_, err := balance.UnmarshalJSON([]byte(invalid))
+		assert.Error(t, err, invalid)
+	}
+}
+
+func TestUnmarshalBalancesJSON(t *testing.T) {
+	bs := balance.Balances{
+		newTestBalance(t, 2000, balance.Amount(1)),
+		newTestBalance(t, 2001, balance.Amount(2), balance.CurrencyCode(newTestCurrency(t, "EUR"))),
+	}
+	data, err := json.Marshal(bs)
+	common.FatalIfError(t, err, "Marshalling JSON")
+	unmarshalled, err := balance.UnmarshalBalancesJSON(data)
+	common.FatalIfError(t, err, "Unmarshalling JSON")
+	if assert.Len(t, unmarshalled, len(bs)) {
+		for i := range bs {
+			assert.True(t, bs[i].Equal(unmarshalled[i]), "json: %s", data)
+		}
+	}
+
+	_, err = balance.UnmarshalBalancesJSON([]byte(`[{"Amount":1}]`))
+	assert.Error(t, err)
+}
@@ -1,5 +1,7 @@
 package balance
 
+import "github.com/glynternet/go-money/currency"
+
 // Option is a function that takes a pointer to a Balance returning an error.
 // The idea of Option is to alter a Balance object
 type Option func(*Balance) error
@@ -11,3 +13,11 @@ func Amount(a int) Option {
 		return nil
 	}
 }
+
+// CurrencyCode is an Option that will alter the Currency of a Balance object.
+func CurrencyCode(c currency.Code) Option {
+	return func(b *Balance) error {
+		b.Currency = c
+		return nil
+	}
+}
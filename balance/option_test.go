@@ -27,5 +27,11 @@ func TestErrorOption(t *testing.T) {
 }
 
 func TestCurrencyCode(t *testing.T) {
-
+	b, err := balance.New(time.Now())
+	common.FatalIfError(t, err, "Creating balance")
+	assert.False(t, b.HasCurrency())
+	c := newTestCurrency(t, "GBP")
+	assert.Nil(t, balance.CurrencyCode(c)(b))
+	assert.Equal(t, c, b.Currency)
+	assert.True(t, b.HasCurrency())
 }
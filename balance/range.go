@@ -0,0 +1,20 @@
+package balance
+
+import gohtime "github.com/glynternet/go-time"
+
+// InRange returns the Balances with a Date within the given Range, in the
+// order that they are held.
+// Containment is decided by Range.Contains: the start of the Range is
+// inclusive and the end is exclusive, so a Balance dated exactly at the end of
+// the Range is excluded. This differs from Account.ValidateBalance and
+// Account.ClipBalances, which both accept a Balance at the close time of an
+// Account.
+func (bs Balances) InRange(r gohtime.Range) Balances {
+	var in Balances
+	for _, b := range bs {
+		if r.Contains(b.Date) {
+			in = append(in, b)
+		}
+	}
+	return in
+}
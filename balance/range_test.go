@@ -0,0 +1,62 @@
+package balance_test
+
+import (
+	"testing"
+
+	"github.com/glynternet/go-accounting/accountingtest"
+	"github.com/glynternet/go-accounting/balance"
+	gtime "github.com/glynternet/go-time"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBalances_InRange(t *testing.T) {
+	bs := balance.Balances{
+		{Date: accountingtest.Date(2020, 6, 1), Amount: 6},
+		{Date: accountingtest.Date(2020, 2, 1), Amount: 2},
+		{Date: accountingtest.Date(2020, 3, 1), Amount: 3},
+		{Date: accountingtest.Date(2020, 5, 31), Amount: 5},
+		{Date: accountingtest.Date(2020, 3, 1), Amount: 33},
+	}
+	for _, test := range []struct {
+		name     string
+		os       []gtime.Option
+		expected balance.Balances
+	}{
+		{
+			name: "start inclusive and end exclusive",
+			os:   []gtime.Option{gtime.Start(accountingtest.Date(2020, 3, 1)), gtime.End(accountingtest.Date(2020, 6, 1))},
+			expected: balance.Balances{
+				{Date: accountingtest.Date(2020, 3, 1), Amount: 3},
+				{Date: accountingtest.Date(2020, 5, 31), Amount: 5},
+				{Date: accountingtest.Date(2020, 3, 1), Amount: 33},
+			},
+		},
+		{
+			name: "no end",
+			os:   []gtime.Option{gtime.Start(accountingtest.Date(2020, 5, 31))},
+			expected: balance.Balances{
+				{Date: accountingtest.Date(2020, 6, 1), Amount: 6},
+				{Date: accountingtest.Date(2020, 5, 31), Amount: 5},
+			},
+		},
+		{
+			name: "no start",
+			os:   []gtime.Option{gtime.End(accountingtest.Date(2020, 3, 1))},
+			expected: balance.Balances{
+				{Date: accountingtest.Date(2020, 2, 1), Amount: 2},
+			},
+		},
+		{
+			name:     "unbounded",
+			expected: bs,
+		},
+		{
+			name: "none within",
+			os:   []gtime.Option{gtime.Start(accountingtest.Date(2021, 1, 1))},
+		},
+	} {
+		r, err := gtime.New(test.os...)
+		assert.Nil(t, err, test.name)
+		assert.Equal(t, test.expected, bs.InRange(*r), test.name)
+	}
+}
@@ -0,0 +1,47 @@
+package balance
+
+import (
+	"time"
+
+	gohtime "github.com/glynternet/go-time"
+)
+
+// Resample returns a regular series of Balances at each of the times from
+// start, stepping by the Interval, up to and including end.
+// The Balance at each time is found using AtTime, so the Amount is that of the
+// latest Balance at or before that time. No Balance is returned for times
+// before the earliest Balance.
+// Resample returns an error if the Interval is invalid.
+func (bs Balances) Resample(start, end time.Time, i Interval) (Balances, error) {
+	ts, err := i.Times(start, end)
+	if err != nil {
+		return nil, err
+	}
+	var resampled Balances
+	for _, t := range ts {
+		b, err := bs.AtTime(t)
+		if err != nil {
+			continue
+		}
+		resampled = append(resampled, Balance{Date: t, Amount: b.Amount, Currency: b.Currency})
+	}
+	return resampled, nil
+}
+
+// ResampleWithin returns the same Balances as Resample, excluding any that
+// would fall outside of the given Range.
+// A time at exactly the end of the Range is included, so that passing
+// Account.TimeRange() gives only Balances that Account.ValidateBalance accepts.
+func (bs Balances) ResampleWithin(r gohtime.Range, start, end time.Time, i Interval) (Balances, error) {
+	resampled, err := bs.Resample(start, end, i)
+	if err != nil {
+		return nil, err
+	}
+	var within Balances
+	for _, b := range resampled {
+		if r.Contains(b.Date) || r.End().EqualTime(b.Date) {
+			within = append(within, b)
+		}
+	}
+	return within, nil
+}
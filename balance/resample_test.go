@@ -0,0 +1,63 @@
+package balance_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/glynternet/go-accounting/accountingtest"
+	"github.com/glynternet/go-accounting/balance"
+	gtime "github.com/glynternet/go-time"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBalances_Resample(t *testing.T) {
+	gbp := newTestCurrency(t, "GBP")
+	bs := balance.Balances{
+		{Date: accountingtest.Date(2020, 1, 15), Amount: 100, Currency: gbp},
+		{Date: accountingtest.Date(2020, 3, 1), Amount: 300, Currency: gbp},
+		{Date: accountingtest.Date(2020, 2, 10), Amount: 200, Currency: gbp},
+		{Date: accountingtest.Date(2020, 3, 1), Amount: 350, Currency: gbp},
+	}
+
+	resampled, err := bs.Resample(accountingtest.Date(2020, 1, 1), accountingtest.Date(2020, 4, 1), balance.Month)
+	assert.Nil(t, err)
+	assert.Equal(t, balance.Balances{
+		{Date: accountingtest.Date(2020, 2, 1), Amount: 100, Currency: gbp},
+		{Date: accountingtest.Date(2020, 3, 1), Amount: 350, Currency: gbp},
+		{Date: accountingtest.Date(2020, 4, 1), Amount: 350, Currency: gbp},
+	}, resampled)
+
+	resampled, err = bs.Resample(accountingtest.Date(2020, 2, 9), accountingtest.Date(2020, 2, 11), balance.Day)
+	assert.Nil(t, err)
+	assert.Equal(t, balance.Balances{
+		{Date: accountingtest.Date(2020, 2, 9), Amount: 100, Currency: gbp},
+		{Date: accountingtest.Date(2020, 2, 10), Amount: 200, Currency: gbp},
+		{Date: accountingtest.Date(2020, 2, 11), Amount: 200, Currency: gbp},
+	}, resampled)
+
+	resampled, err = balance.Balances{}.Resample(accountingtest.Date(2020, 1, 1), accountingtest.Date(2021, 1, 1), balance.Year)
+	assert.Nil(t, err)
+	assert.Empty(t, resampled)
+
+	_, err = bs.Resample(accountingtest.Date(2020, 1, 1), accountingtest.Date(2021, 1, 1), balance.Interval(0))
+	assert.Equal(t, errors.New(balance.ErrInvalidInterval), err)
+}
+
+func TestBalances_ResampleWithin(t *testing.T) {
+	bs := balance.Balances{
+		{Date: accountingtest.Date(2020, 1, 1), Amount: 100},
+	}
+	r, err := gtime.New(gtime.Start(accountingtest.Date(2020, 2, 1)), gtime.End(accountingtest.Date(2020, 4, 1)))
+	assert.Nil(t, err)
+
+	resampled, err := bs.ResampleWithin(*r, accountingtest.Date(2020, 1, 1), accountingtest.Date(2020, 6, 1), balance.Month)
+	assert.Nil(t, err)
+	assert.Equal(t, balance.Balances{
+		{Date: accountingtest.Date(2020, 2, 1), Amount: 100},
+		{Date: accountingtest.Date(2020, 3, 1), Amount: 100},
+		{Date: accountingtest.Date(2020, 4, 1), Amount: 100},
+	}, resampled)
+
+	_, err = bs.ResampleWithin(*r, accountingtest.Date(2020, 1, 1), accountingtest.Date(2020, 6, 1), balance.Interval(0))
+	assert.Equal(t, errors.New(balance.ErrInvalidInterval), err)
+}
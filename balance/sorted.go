@@ -0,0 +1,93 @@
+package balance
+
+import (
+	"errors"
+	"sort"
+	"time"
+)
+
+// NewSorted creates a Sorted from the given Balances.
+// The Balances are copied and sorted by Date. Balances with equal Dates keep
+// the order in which they were given, so that Sorted gives the same results
+// as the equivalent Balances methods.
+func NewSorted(bs Balances) Sorted {
+	sorted := append(Balances(nil), bs...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Date.Before(sorted[j].Date)
+	})
+	return Sorted{balances: sorted}
+}
+
+// Sorted holds Balances in Date order, so that queries by time can be
+// answered without scanning every Balance.
+// Earliest and Latest take constant time, AtTime and Between take
+// logarithmic time in the number of Balances held.
+type Sorted struct {
+	balances Balances
+}
+
+// Len returns the number of Balances held in the Sorted.
+func (s Sorted) Len() int {
+	return len(s.balances)
+}
+
+// Balances returns a copy of the Balances held in the Sorted, in Date order.
+func (s Sorted) Balances() Balances {
+	return append(Balances(nil), s.balances...)
+}
+
+// Earliest returns the Balance with the earliest Date.
+// If multiple Balance objects have the same Date, the Balance encountered
+// first will be returned, as with Balances.Earliest.
+func (s Sorted) Earliest() (Balance, error) {
+	if len(s.balances) == 0 {
+		return Balance{}, errors.New(ErrEmptyBalancesMessage)
+	}
+	return s.balances[0], nil
+}
+
+// Latest returns the Balance with the latest Date.
+// If multiple Balance objects have the same Date, the Balance encountered
+// last will be returned, as with Balances.Latest.
+func (s Sorted) Latest() (Balance, error) {
+	if len(s.balances) == 0 {
+		return Balance{}, errors.New(ErrEmptyBalancesMessage)
+	}
+	return s.balances[len(s.balances)-1], nil
+}
+
+// AtTime returns the latest Balance that is at or before a given time.
+// If multiple Balances have the same date that is the latest, the Balance that
+// was encountered last will be returned, as with Balances.AtTime.
+func (s Sorted) AtTime(t time.Time) (Balance, error) {
+	i := s.after(t)
+	if i == 0 {
+		return Balance{}, errors.New(ErrNoBalances)
+	}
+	return s.balances[i-1], nil
+}
+
+// Between returns the Balances with a Date at or after start and before end,
+// in Date order.
+func (s Sorted) Between(start, end time.Time) Balances {
+	i := s.notBefore(start)
+	j := s.notBefore(end)
+	if i >= j {
+		return nil
+	}
+	return append(Balances(nil), s.balances[i:j]...)
+}
+
+// after returns the index of the first Balance with a Date after t.
+func (s Sorted) after(t time.Time) int {
+	return sort.Search(len(s.balances), func(i int) bool {
+		return s.balances[i].Date.After(t)
+	})
+}
+
+// notBefore returns the index of the first Balance with a Date at or after t.
+func (s Sorted) notBefore(t time.Time) int {
+	return sort.Search(len(s.balances), func(i int) bool {
+		return !s.balances[i].Date.Before(t)
+	})
+}
@@ -0,0 +1,182 @@
+package balance_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/glynternet/go-accounting/accountingtest"
+	"github.com/glynternet/go-accounting/balance"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewSorted(t *testing.T) {
+	bs := balance.Balances{
+		{Date: accountingtest.Date(2020, 3, 1), Amount: 3},
+		{Date: accountingtest.Date(2020, 1, 1), Amount: 1},
+		{Date: accountingtest.Date(2020, 2, 1), Amount: 2},
+		{Date: accountingtest.Date(2020, 1, 1), Amount: 11},
+	}
+	s := balance.NewSorted(bs)
+	assert.Equal(t, 4, s.Len())
+	assert.Equal(t, balance.Balances{
+		{Date: accountingtest.Date(2020, 1, 1), Amount: 1},
+		{Date: accountingtest.Date(2020, 1, 1), Amount: 11},
+		{Date: accountingtest.Date(2020, 2, 1), Amount: 2},
+		{Date: accountingtest.Date(2020, 3, 1), Amount: 3},
+	}, s.Balances())
+	assert.Equal(t, 3, bs[0].Amount, "original Balances should be unaltered")
+}
+
+func TestSorted_MatchesBalances(t *testing.T) {
+	bs := balance.Balances{
+		{Date: accountingtest.Date(2020, 2, 1), Amount: 2},
+		{Date: accountingtest.Date(2020, 1, 1), Amount: 1},
+		{Date: accountingtest.Date(2020, 2, 1), Amount: 22},
+		{Date: accountingtest.Date(2020, 1, 1), Amount: 11},
+		{Date: accountingtest.Date(2020, 3, 1), Amount: 3},
+		{Date: accountingtest.Date(2020, 3, 1), Amount: 33},
+	}
+	s := balance.NewSorted(bs)
+
+	expected, expectedErr := bs.Earliest()
+	actual, err := s.Earliest()
+	assert.Equal(t, expectedErr, err)
+	assert.Equal(t, expected, actual)
+
+	expected, expectedErr = bs.Latest()
+	actual, err = s.Latest()
+	assert.Equal(t, expectedErr, err)
+	assert.Equal(t, expected, actual)
+
+	for _, at := range []time.Time{
+		accountingtest.Date(2019, 12, 31),
+		accountingtest.Date(2020, 1, 1),
+		accountingtest.Date(2020, 1, 15),
+		accountingtest.Date(2020, 2, 1),
+		accountingtest.Date(2020, 3, 1),
+		accountingtest.Date(2021, 1, 1),
+	} {
+		expected, expectedErr := bs.AtTime(at)
+		actual, err := s.AtTime(at)
+		assert.Equal(t, expectedErr, err, at.String())
+		assert.Equal(t, expected, actual, at.String())
+	}
+}
+
+func TestSorted_Empty(t *testing.T) {
+	s := balance.NewSorted(nil)
+	_, err := s.Earliest()
+	assert.Equal(t, errors.New(balance.ErrEmptyBalancesMessage), err)
+	_, err = s.Latest()
+	assert.Equal(t, errors.New(balance.ErrEmptyBalancesMessage), err)
+	_, err = s.AtTime(accountingtest.Date(2020, 1, 1))
+	assert.Equal(t, errors.New(balance.ErrNoBalances), err)
+	assert.Empty(t, s.Between(accountingtest.Date(2000, 1, 1), accountingtest.Date(2030, 1, 1)))
+}
+
+func TestSorted_Between(t *testing.T) {
+	s := balance.NewSorted(balance.Balances{
+		{Date: accountingtest.Date(2020, 3, 1), Amount: 3},
+		{Date: accountingtest.Date(2020, 1, 1), Amount: 1},
+		{Date: accountingtest.Date(2020, 2, 1), Amount: 2},
+		{Date: accountingtest.Date(2020, 2, 1), Amount: 22},
+	})
+	for _, test := range []struct {
+		name       string
+		start, end time.Time
+		expected   balance.Balances
+	}{
+		{
+			name:  "start inclusive and end exclusive",
+			start: accountingtest.Date(2020, 1, 1), end: accountingtest.Date(2020, 3, 1),
+			expected: balance.Balances{
+				{Date: accountingtest.Date(2020, 1, 1), Amount: 1},
+				{Date: accountingtest.Date(2020, 2, 1), Amount: 2},
+				{Date: accountingtest.Date(2020, 2, 1), Amount: 22},
+			},
+		},
+		{
+			name:  "within",
+			start: accountingtest.Date(2020, 1, 2), end: accountingtest.Date(2020, 3, 2),
+			expected: balance.Balances{
+				{Date: accountingtest.Date(2020, 2, 1), Amount: 2},
+				{Date: accountingtest.Date(2020, 2, 1), Amount: 22},
+				{Date: accountingtest.Date(2020, 3, 1), Amount: 3},
+			},
+		},
+		{name: "before all", start: accountingtest.Date(2019, 1, 1), end: accountingtest.Date(2020, 1, 1)},
+		{name: "end before start", start: accountingtest.Date(2020, 3, 1), end: accountingtest.Date(2020, 1, 1)},
+	} {
+		assert.Equal(t, test.expected, s.Between(test.start, test.end), test.name)
+	}
+}
+
+func benchmarkBalances(n int) balance.Balances {
+	bs := make(balance.Balances, n)
+	start := accountingtest.Date(2000, 1, 1)
+	for i := range bs {
+		bs[i] = balance.Balance{Date: start.AddDate(0, 0, i), Amount: i}
+	}
+	return bs
+}
+
+const benchmarkSize = 10 * 365
+
+func BenchmarkBalances_AtTime(b *testing.B) {
+	bs := benchmarkBalances(benchmarkSize)
+	at := accountingtest.Date(2005, 6, 15)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = bs.AtTime(at)
+	}
+}
+
+func BenchmarkSorted_AtTime(b *testing.B) {
+	s := balance.NewSorted(benchmarkBalances(benchmarkSize))
+	at := accountingtest.Date(2005, 6, 15)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = s.AtTime(at)
+	}
+}
+
+func BenchmarkBalances_Latest(b *testing.B) {
+	bs := benchmarkBalances(benchmarkSize)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = bs.Latest()
+	}
+}
+
+func BenchmarkSorted_Latest(b *testing.B) {
+	s := balance.NewSorted(benchmarkBalances(benchmarkSize))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = s.Latest()
+	}
+}
+
+func BenchmarkBalances_Earliest(b *testing.B) {
+	bs := benchmarkBalances(benchmarkSize)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = bs.Earliest()
+	}
+}
+
+func BenchmarkSorted_Earliest(b *testing.B) {
+	s := balance.NewSorted(benchmarkBalances(benchmarkSize))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = s.Earliest()
+	}
+}
+
+func BenchmarkNewSorted(b *testing.B) {
+	bs := benchmarkBalances(benchmarkSize)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		balance.NewSorted(bs)
+	}
+}
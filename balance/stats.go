@@ -0,0 +1,83 @@
+package balance
+
+import (
+	"errors"
+	"time"
+
+	gohtime "github.com/glynternet/go-time"
+)
+
+// Various error strings describing possible errors when summarising Balances.
+const (
+	ErrUnboundedRange = "Range must have both a start and an end"
+	ErrEmptyRange     = "Range end must be after its start"
+)
+
+// Stats holds time-weighted statistics of Balances over a period of time.
+type Stats struct {
+	// Mean is the average Amount over the period, weighted by the length of
+	// time that each Amount was held for.
+	Mean float64
+	// Min and Max are the lowest and highest Amounts held over the period,
+	// dated at the time that they were first held within the period.
+	Min Balance
+	Max Balance
+	// Overdrawn is the total length of time that the Amount was below zero.
+	Overdrawn time.Duration
+}
+
+// Stats returns the time-weighted Stats of the Balances over the given Range.
+// The Amount held at any time is that given by AtTime, so that each Balance
+// holds until the Date of the next Balance. Where several Balances share a
+// Date, only the last is considered held.
+// The Range must have both a start and an end, with the end after the start,
+// and there must be a Balance at or before the start of the Range, otherwise
+// an error is returned. An ErrMixedCurrencies error is returned if the
+// Balances held over the Range contain more than one currency.
+func (bs Balances) Stats(r gohtime.Range) (Stats, error) {
+	if !r.Start().Valid || !r.End().Valid {
+		return Stats{}, errors.New(ErrUnboundedRange)
+	}
+	start, end := r.Start().Time, r.End().Time
+	if !end.After(start) {
+		return Stats{}, errors.New(ErrEmptyRange)
+	}
+	sorted := NewSorted(bs)
+	first, err := sorted.AtTime(start)
+	if err != nil {
+		return Stats{}, err
+	}
+	held := Balances{{Date: start, Amount: first.Amount, Currency: first.Currency}}
+	changes := sorted.Between(start, end)
+	for i, b := range changes {
+		if !b.Date.After(start) || i+1 < len(changes) && changes[i+1].Date.Equal(b.Date) {
+			continue
+		}
+		held = append(held, b)
+	}
+	if len(held.SumByCurrency()) > 1 {
+		return Stats{}, errors.New(ErrMixedCurrencies)
+	}
+
+	s := Stats{Min: held[0], Max: held[0]}
+	var weighted float64
+	for i, b := range held {
+		until := end
+		if i+1 < len(held) {
+			until = held[i+1].Date
+		}
+		d := until.Sub(b.Date)
+		weighted += float64(b.Amount) * float64(d)
+		if b.Amount < 0 {
+			s.Overdrawn += d
+		}
+		if b.Amount < s.Min.Amount {
+			s.Min = b
+		}
+		if b.Amount > s.Max.Amount {
+			s.Max = b
+		}
+	}
+	s.Mean = weighted / float64(end.Sub(start))
+	return s, nil
+}
@@ -0,0 +1,80 @@
+package balance_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/glynternet/go-accounting/accountingtest"
+	"github.com/glynternet/go-accounting/balance"
+	"github.com/glynternet/go-money/common"
+	gtime "github.com/glynternet/go-time"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBalances_Stats(t *testing.T) {
+	gbp := newTestCurrency(t, "GBP")
+	bs := balance.Balances{
+		{Date: accountingtest.Date(2020, 1, 1), Amount: 100, Currency: gbp},
+		{Date: accountingtest.Date(2020, 1, 5), Amount: -50, Currency: gbp},
+		{Date: accountingtest.Date(2020, 1, 8), Amount: 300, Currency: gbp},
+		{Date: accountingtest.Date(2020, 1, 8), Amount: 200, Currency: gbp},
+		{Date: accountingtest.Date(2020, 1, 10), Amount: -50, Currency: gbp},
+		{Date: accountingtest.Date(2020, 1, 11), Amount: 1000, Currency: gbp},
+	}
+	r := newTestRange(t, accountingtest.Date(2020, 1, 3), accountingtest.Date(2020, 1, 11))
+
+	s, err := bs.Stats(r)
+	assert.Nil(t, err)
+	// 2 days at 100, 3 at -50, 2 at 200, 1 at -50
+	assert.InDelta(t, float64(200-150+400-50)/8, s.Mean, 1e-9)
+	assert.Equal(t, balance.Balance{Date: accountingtest.Date(2020, 1, 5), Amount: -50, Currency: gbp}, s.Min)
+	assert.Equal(t, balance.Balance{Date: accountingtest.Date(2020, 1, 8), Amount: 200, Currency: gbp}, s.Max)
+	assert.Equal(t, 4*24*time.Hour, s.Overdrawn)
+}
+
+func TestBalances_Stats_StartOnBalance(t *testing.T) {
+	bs := balance.Balances{
+		{Date: accountingtest.Date(2020, 1, 1), Amount: 10},
+		{Date: accountingtest.Date(2020, 1, 3), Amount: 10},
+	}
+	s, err := bs.Stats(newTestRange(t, accountingtest.Date(2020, 1, 1), accountingtest.Date(2020, 1, 5)))
+	assert.Nil(t, err)
+	assert.Equal(t, balance.Stats{
+		Mean: 10,
+		Min:  balance.Balance{Date: accountingtest.Date(2020, 1, 1), Amount: 10},
+		Max:  balance.Balance{Date: accountingtest.Date(2020, 1, 1), Amount: 10},
+	}, s)
+}
+
+func TestBalances_Stats_Errors(t *testing.T) {
+	bs := balance.Balances{
+		{Date: accountingtest.Date(2020, 1, 1), Amount: 10, Currency: newTestCurrency(t, "GBP")},
+		{Date: accountingtest.Date(2020, 1, 3), Amount: 10, Currency: newTestCurrency(t, "EUR")},
+	}
+	unbounded, err := gtime.New(gtime.Start(accountingtest.Date(2020, 1, 1)))
+	assert.Nil(t, err)
+
+	for _, test := range []struct {
+		name string
+		gtime.Range
+		err error
+	}{
+		{name: "unbounded", Range: *unbounded, err: errors.New(balance.ErrUnboundedRange)},
+		{name: "empty", Range: newTestRange(t, accountingtest.Date(2020, 1, 2), accountingtest.Date(2020, 1, 2)), err: errors.New(balance.ErrEmptyRange)},
+		{name: "before Balances", Range: newTestRange(t, accountingtest.Date(2019, 1, 1), accountingtest.Date(2020, 1, 2)), err: errors.New(balance.ErrNoBalances)},
+		{name: "mixed currencies", Range: newTestRange(t, accountingtest.Date(2020, 1, 2), accountingtest.Date(2020, 1, 4)), err: errors.New(balance.ErrMixedCurrencies)},
+	} {
+		_, err := bs.Stats(test.Range)
+		assert.Equal(t, test.err, err, test.name)
+	}
+
+	_, err = bs.Stats(newTestRange(t, accountingtest.Date(2020, 1, 1), accountingtest.Date(2020, 1, 3)))
+	assert.Nil(t, err, "Balance of other currency at end of Range is not held")
+}
+
+func newTestRange(t *testing.T, start, end time.Time) gtime.Range {
+	r, err := gtime.New(gtime.Start(start), gtime.End(end))
+	common.FatalIfError(t, err, "Creating Range")
+	return *r
+}
@@ -0,0 +1,64 @@
+package budget
+
+import (
+	"time"
+
+	"github.com/glynternet/go-accounting/account"
+	"github.com/glynternet/go-accounting/balance"
+	"github.com/glynternet/go-accounting/cashflow"
+	"github.com/glynternet/go-accounting/transaction"
+	"github.com/glynternet/go-money/currency"
+)
+
+// Actual is an amount of money that moved in a category at a given time.
+// As with Transactions, money spent is negative and money received is
+// positive.
+// An Actual with a zero-value Currency has no currency attached to it.
+type Actual struct {
+	Date     time.Time
+	Category string
+	Amount   int
+	Currency currency.Code
+}
+
+// FromTransactions returns an Actual for each of the Transactions that has a
+// Category. Transactions without a Category are skipped.
+// Each Actual is in the currency of the Account of its Transaction, or has no
+// currency if the Transaction references no Account.
+func FromTransactions(ts transaction.Transactions) []Actual {
+	var as []Actual
+	for _, t := range ts {
+		if t.Category == "" {
+			continue
+		}
+		a := Actual{Date: t.Date, Category: t.Category, Amount: t.Amount}
+		if t.Account != nil {
+			a.Currency = t.Account.CurrencyCode()
+		}
+		as = append(as, a)
+	}
+	return as
+}
+
+// FromBalances returns an Actual in the given category for each change
+// between consecutive Balances of an Account, dated at the later Balance and
+// in the currency of the Account.
+// This allows an Account that is used for a single purpose, such as a card
+// used only for fuel, to be budgeted without its Transactions.
+// FromBalances returns an error if any Balance is invalid for the Account.
+func FromBalances(a account.Account, bs balance.Balances, category string) ([]Actual, error) {
+	for _, b := range bs {
+		if err := a.ValidateBalance(b); err != nil {
+			return nil, err
+		}
+	}
+	ds, err := cashflow.New(bs)
+	if err != nil {
+		return nil, err
+	}
+	var as []Actual
+	for _, d := range ds {
+		as = append(as, Actual{Date: d.To, Category: category, Amount: d.Amount, Currency: a.CurrencyCode()})
+	}
+	return as, nil
+}
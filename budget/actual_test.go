@@ -0,0 +1,42 @@
+package budget_test
+
+import (
+	"testing"
+
+	"github.com/glynternet/go-accounting/accountingtest"
+	"github.com/glynternet/go-accounting/balance"
+	"github.com/glynternet/go-accounting/budget"
+	"github.com/glynternet/go-accounting/transaction"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFromTransactions(t *testing.T) {
+	eur := accountingtest.NewCurrencyCode(t, "EUR")
+	card := accountingtest.NewAccount(t, "Card", eur, accountingtest.Date(2020, 1, 1))
+	assert.Equal(t, []budget.Actual{
+		{Date: accountingtest.Date(2020, 1, 2), Category: "food", Amount: -12},
+		{Date: accountingtest.Date(2020, 1, 3), Category: "food", Amount: -7, Currency: eur},
+	}, budget.FromTransactions(transaction.Transactions{
+		{Date: accountingtest.Date(2020, 1, 1), Amount: -5},
+		{Date: accountingtest.Date(2020, 1, 2), Amount: -12, Category: "food"},
+		{Date: accountingtest.Date(2020, 1, 3), Amount: -7, Category: "food", Account: card},
+	}))
+}
+
+func TestFromBalances(t *testing.T) {
+	gbp := accountingtest.NewCurrencyCode(t, "GBP")
+	a := *accountingtest.NewAccount(t, "Fuel card", gbp, accountingtest.Date(2020, 1, 1))
+	as, err := budget.FromBalances(a, balance.Balances{
+		{Date: accountingtest.Date(2020, 1, 10), Amount: -40},
+		{Date: accountingtest.Date(2020, 1, 1), Amount: 0},
+		{Date: accountingtest.Date(2020, 1, 20), Amount: -100},
+	}, "fuel")
+	assert.Nil(t, err)
+	assert.Equal(t, []budget.Actual{
+		{Date: accountingtest.Date(2020, 1, 10), Category: "fuel", Amount: -40, Currency: gbp},
+		{Date: accountingtest.Date(2020, 1, 20), Category: "fuel", Amount: -60, Currency: gbp},
+	}, as)
+
+	_, err = budget.FromBalances(a, balance.Balances{{Date: accountingtest.Date(2019, 1, 1)}}, "fuel")
+	assert.IsType(t, balance.DateOutOfAccountTimeRange{}, err)
+}
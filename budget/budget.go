@@ -0,0 +1,214 @@
+// Package budget compares the money spent in each category against the
+// limits set for it in each period of a Budget.
+package budget
+
+import (
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/glynternet/go-accounting/balance"
+	"github.com/glynternet/go-money/currency"
+)
+
+// Various error messages describing possible errors when using a Budget.
+const (
+	ErrEmptyCategory     = "empty category"
+	ErrDuplicateCategory = "duplicate category"
+	ErrUnknownCategory   = "unknown category"
+	ErrNegativeLimit     = "limit must not be negative"
+	ErrBeforeStart       = "time is before the start of the Budget"
+	ErrMixedCurrencies   = "Actuals in a category contain multiple currencies"
+)
+
+// Envelope holds the amount that can be spent in a category in each period of
+// a Budget.
+// When Rollover is true, any amount left at the end of a period is added to
+// the next period and any amount overspent is taken from it.
+type Envelope struct {
+	Category string
+	Limit    int
+	Rollover bool
+}
+
+// New creates a new Budget with periods of the given Interval, the first
+// starting at start, and an Envelope for each category.
+// New returns an error if the Interval is invalid, any Envelope has an empty
+// category or negative Limit, or a category has more than one Envelope.
+func New(start time.Time, period balance.Interval, es ...Envelope) (*Budget, error) {
+	if !period.Valid() {
+		return nil, errors.New(balance.ErrInvalidInterval)
+	}
+	b := &Budget{start: start, period: period, limits: make(map[string]map[int]int)}
+	seen := make(map[string]bool)
+	for _, e := range es {
+		switch {
+		case e.Category == "":
+			return nil, errors.New(ErrEmptyCategory)
+		case e.Limit < 0:
+			return nil, errors.New(ErrNegativeLimit)
+		case seen[e.Category]:
+			return nil, errors.New(ErrDuplicateCategory)
+		}
+		seen[e.Category] = true
+		b.envelopes = append(b.envelopes, e)
+	}
+	return b, nil
+}
+
+// Budget holds the Envelopes of a budget over consecutive periods of time.
+type Budget struct {
+	start     time.Time
+	period    balance.Interval
+	envelopes []Envelope
+	limits    map[string]map[int]int
+}
+
+// SetLimit sets the Limit of the Envelope for a category in the single period
+// that contains the given time, overriding the Envelope's usual Limit.
+func (b *Budget) SetLimit(category string, t time.Time, limit int) error {
+	if _, ok := b.envelope(category); !ok {
+		return errors.New(ErrUnknownCategory)
+	}
+	if limit < 0 {
+		return errors.New(ErrNegativeLimit)
+	}
+	n, ok := b.periodOf(t)
+	if !ok {
+		return errors.New(ErrBeforeStart)
+	}
+	if b.limits[category] == nil {
+		b.limits[category] = make(map[int]int)
+	}
+	b.limits[category][n] = limit
+	return nil
+}
+
+// Line holds the state of a single Envelope over a single period.
+// Available is the Limit plus any amount RolledOver from the previous period.
+// Spent is the money spent in the period, less any money received, so is
+// positive when money has been spent. Remaining is the amount of Available
+// that has not been Spent and Overspent is the amount Spent beyond Available;
+// at most one of them is greater than zero.
+type Line struct {
+	Category   string
+	Start      time.Time
+	End        time.Time
+	Limit      int
+	RolledOver int
+	Available  int
+	Spent      int
+	Remaining  int
+	Overspent  int
+}
+
+// Report returns a Line for each Envelope in each period of the Budget, from
+// the first period up to and including the period that contains until, in
+// period order and then in the order that the Envelopes were given.
+// Each Actual is counted in the period that contains its Date. Actuals before
+// the start of the Budget, or after the last period, are ignored. Actuals in
+// a category that has no Envelope are reported in a Line with a zero Limit,
+// after the Envelopes of the period and in category order, so that
+// unbudgeted spending is not hidden.
+// As the amounts of a category are summed, Report returns an
+// ErrMixedCurrencies error if the counted Actuals of any category are in more
+// than one currency. Actuals without a currency are considered to be a
+// currency of their own, in the same way as with balance.Balances.Sum.
+func (b Budget) Report(until time.Time, as []Actual) ([]Line, error) {
+	starts, err := b.period.Times(b.start, until)
+	if err != nil {
+		return nil, err
+	}
+	spent := make([]map[string]int, len(starts))
+	for i := range spent {
+		spent[i] = make(map[string]int)
+	}
+	var unbudgeted []string
+	currencies := make(map[string]currency.Code)
+	for _, a := range as {
+		n, ok := b.periodOf(a.Date)
+		if !ok || n >= len(starts) {
+			continue
+		}
+		if c, ok := currencies[a.Category]; ok && c != a.Currency {
+			return nil, errors.New(ErrMixedCurrencies)
+		}
+		currencies[a.Category] = a.Currency
+		if _, ok := b.envelope(a.Category); !ok && !contains(unbudgeted, a.Category) {
+			unbudgeted = append(unbudgeted, a.Category)
+		}
+		spent[n][a.Category] -= a.Amount
+	}
+	sort.Strings(unbudgeted)
+
+	var lines []Line
+	carried := make(map[string]int)
+	for n, start := range starts {
+		end := b.period.Add(b.start, n+1)
+		for _, e := range b.envelopes {
+			limit := e.Limit
+			if l, ok := b.limits[e.Category][n]; ok {
+				limit = l
+			}
+			l := newLine(e.Category, start, end, limit, carried[e.Category], spent[n][e.Category])
+			if e.Rollover {
+				carried[e.Category] = l.Available - l.Spent
+			}
+			lines = append(lines, l)
+		}
+		for _, c := range unbudgeted {
+			if s, ok := spent[n][c]; ok {
+				lines = append(lines, newLine(c, start, end, 0, 0, s))
+			}
+		}
+	}
+	return lines, nil
+}
+
+func newLine(category string, start, end time.Time, limit, rolledOver, spent int) Line {
+	l := Line{
+		Category:   category,
+		Start:      start,
+		End:        end,
+		Limit:      limit,
+		RolledOver: rolledOver,
+		Available:  limit + rolledOver,
+		Spent:      spent,
+	}
+	if l.Spent > l.Available {
+		l.Overspent = l.Spent - l.Available
+	} else {
+		l.Remaining = l.Available - l.Spent
+	}
+	return l
+}
+
+// periodOf returns the index of the period that contains the given time.
+func (b Budget) periodOf(t time.Time) (int, bool) {
+	if t.Before(b.start) {
+		return 0, false
+	}
+	n := 0
+	for !t.Before(b.period.Add(b.start, n+1)) {
+		n++
+	}
+	return n, true
+}
+
+func (b Budget) envelope(category string) (Envelope, bool) {
+	for _, e := range b.envelopes {
+		if e.Category == category {
+			return e, true
+		}
+	}
+	return Envelope{}, false
+}
+
+func contains(ss []string, s string) bool {
+	for _, x := range ss {
+		if x == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,105 @@
+package budget_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/glynternet/go-accounting/accountingtest"
+	"github.com/glynternet/go-accounting/balance"
+	"github.com/glynternet/go-accounting/budget"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNew(t *testing.T) {
+	for _, test := range []struct {
+		name string
+		balance.Interval
+		es  []budget.Envelope
+		err error
+	}{
+		{name: "valid", Interval: balance.Month, es: []budget.Envelope{{Category: "food", Limit: 100}, {Category: "fuel"}}},
+		{name: "invalid interval", es: []budget.Envelope{{Category: "food"}}, err: errors.New(balance.ErrInvalidInterval)},
+		{name: "empty category", Interval: balance.Month, es: []budget.Envelope{{Limit: 1}}, err: errors.New(budget.ErrEmptyCategory)},
+		{name: "negative limit", Interval: balance.Month, es: []budget.Envelope{{Category: "food", Limit: -1}}, err: errors.New(budget.ErrNegativeLimit)},
+		{name: "duplicate", Interval: balance.Month, es: []budget.Envelope{{Category: "food"}, {Category: "food"}}, err: errors.New(budget.ErrDuplicateCategory)},
+	} {
+		b, err := budget.New(accountingtest.Date(2020, 1, 1), test.Interval, test.es...)
+		assert.Equal(t, test.err, err, test.name)
+		assert.Equal(t, test.err == nil, b != nil, test.name)
+	}
+}
+
+func TestBudget_Report(t *testing.T) {
+	b, err := budget.New(accountingtest.Date(2020, 1, 1), balance.Month,
+		budget.Envelope{Category: "food", Limit: 300, Rollover: true},
+		budget.Envelope{Category: "fuel", Limit: 100},
+	)
+	assert.Nil(t, err)
+	assert.Nil(t, b.SetLimit("fuel", accountingtest.Date(2020, 2, 10), 50))
+
+	as := []budget.Actual{
+		{Date: accountingtest.Date(2019, 12, 31), Category: "food", Amount: -1000},
+		{Date: accountingtest.Date(2020, 1, 5), Category: "food", Amount: -200},
+		{Date: accountingtest.Date(2020, 1, 6), Category: "fuel", Amount: -150},
+		{Date: accountingtest.Date(2020, 1, 31), Category: "gifts", Amount: -20},
+		{Date: accountingtest.Date(2020, 2, 1), Category: "food", Amount: -450},
+		{Date: accountingtest.Date(2020, 2, 2), Category: "food", Amount: 30},
+		{Date: accountingtest.Date(2020, 2, 29), Category: "fuel", Amount: -20},
+		{Date: accountingtest.Date(2020, 3, 1), Category: "food", Amount: -1000},
+	}
+	lines, err := b.Report(accountingtest.Date(2020, 2, 15), as)
+	assert.Nil(t, err)
+	assert.Equal(t, []budget.Line{
+		{Category: "food", Start: accountingtest.Date(2020, 1, 1), End: accountingtest.Date(2020, 2, 1), Limit: 300, Available: 300, Spent: 200, Remaining: 100},
+		{Category: "fuel", Start: accountingtest.Date(2020, 1, 1), End: accountingtest.Date(2020, 2, 1), Limit: 100, Available: 100, Spent: 150, Overspent: 50},
+		{Category: "gifts", Start: accountingtest.Date(2020, 1, 1), End: accountingtest.Date(2020, 2, 1), Spent: 20, Overspent: 20},
+		{Category: "food", Start: accountingtest.Date(2020, 2, 1), End: accountingtest.Date(2020, 3, 1), Limit: 300, RolledOver: 100, Available: 400, Spent: 420, Overspent: 20},
+		{Category: "fuel", Start: accountingtest.Date(2020, 2, 1), End: accountingtest.Date(2020, 3, 1), Limit: 50, Available: 50, Spent: 20, Remaining: 30},
+	}, lines)
+
+	lines, err = b.Report(accountingtest.Date(2020, 3, 1), as)
+	assert.Nil(t, err)
+	assert.Equal(t, budget.Line{
+		Category: "food", Start: accountingtest.Date(2020, 3, 1), End: accountingtest.Date(2020, 4, 1),
+		Limit: 300, RolledOver: -20, Available: 280, Spent: 1000, Overspent: 720,
+	}, lines[5])
+
+	lines, err = b.Report(accountingtest.Date(2019, 1, 1), as)
+	assert.Nil(t, err)
+	assert.Empty(t, lines)
+}
+
+func TestBudget_Report_MixedCurrencies(t *testing.T) {
+	b, err := budget.New(accountingtest.Date(2020, 1, 1), balance.Month, budget.Envelope{Category: "food", Limit: 300})
+	assert.Nil(t, err)
+	gbp := accountingtest.NewCurrencyCode(t, "GBP")
+	eur := accountingtest.NewCurrencyCode(t, "EUR")
+
+	lines, err := b.Report(accountingtest.Date(2020, 1, 1), []budget.Actual{
+		{Date: accountingtest.Date(2020, 1, 5), Category: "food", Amount: -200, Currency: gbp},
+		{Date: accountingtest.Date(2020, 1, 6), Category: "food", Amount: -10, Currency: eur},
+	})
+	assert.Equal(t, errors.New(budget.ErrMixedCurrencies), err)
+	assert.Nil(t, lines)
+
+	_, err = b.Report(accountingtest.Date(2020, 1, 1), []budget.Actual{
+		{Date: accountingtest.Date(2020, 1, 5), Category: "gifts", Amount: -200, Currency: gbp},
+		{Date: accountingtest.Date(2020, 1, 6), Category: "gifts", Amount: -10},
+	})
+	assert.Equal(t, errors.New(budget.ErrMixedCurrencies), err)
+
+	_, err = b.Report(accountingtest.Date(2020, 1, 1), []budget.Actual{
+		{Date: accountingtest.Date(2019, 12, 5), Category: "food", Amount: -200, Currency: eur},
+		{Date: accountingtest.Date(2020, 1, 5), Category: "food", Amount: -200, Currency: gbp},
+		{Date: accountingtest.Date(2020, 1, 6), Category: "fuel", Amount: -10, Currency: eur},
+	})
+	assert.Nil(t, err, "Actuals that are not counted, or are in other categories, should not be compared")
+}
+
+func TestBudget_SetLimit(t *testing.T) {
+	b, err := budget.New(accountingtest.Date(2020, 1, 1), balance.Month, budget.Envelope{Category: "food"})
+	assert.Nil(t, err)
+	assert.Equal(t, errors.New(budget.ErrUnknownCategory), b.SetLimit("fuel", accountingtest.Date(2020, 1, 1), 1))
+	assert.Equal(t, errors.New(budget.ErrNegativeLimit), b.SetLimit("food", accountingtest.Date(2020, 1, 1), -1))
+	assert.Equal(t, errors.New(budget.ErrBeforeStart), b.SetLimit("food", accountingtest.Date(2019, 12, 31), 1))
+}
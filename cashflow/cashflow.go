@@ -0,0 +1,180 @@
+// Package cashflow analyses the movement of money between the Balances of an
+// Account, rather than the amount held at any one time.
+package cashflow
+
+import (
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/glynternet/go-accounting/balance"
+	"github.com/glynternet/go-money/currency"
+	gtime "github.com/glynternet/go-time"
+)
+
+// ErrNoDeltas is the error message used when Deltas contains no Delta items.
+const ErrNoDeltas = "no Deltas"
+
+// Delta is the change in Amount between two consecutive Balances.
+type Delta struct {
+	From     time.Time
+	To       time.Time
+	Amount   int
+	Currency currency.Code
+}
+
+// Deltas holds multiple Delta items.
+type Deltas []Delta
+
+// New returns the Deltas between each consecutive pair of the given Balances,
+// once they have been put in Date order.
+// Balances with equal Dates are kept in the order that they were given.
+// New returns an ErrMixedCurrencies error if the Balances contain more than
+// one currency.
+func New(bs balance.Balances) (Deltas, error) {
+	if len(bs.SumByCurrency()) > 1 {
+		return nil, errors.New(balance.ErrMixedCurrencies)
+	}
+	sorted := balance.NewSorted(bs).Balances()
+	var ds Deltas
+	for i := 1; i < len(sorted); i++ {
+		ds = append(ds, Delta{
+			From:     sorted[i-1].Date,
+			To:       sorted[i].Date,
+			Amount:   sorted[i].Amount - sorted[i-1].Amount,
+			Currency: sorted[i].Currency,
+		})
+	}
+	return ds, nil
+}
+
+// Net returns the sum of the Amounts of all of the Deltas.
+func (ds Deltas) Net() int {
+	var net int
+	for _, d := range ds {
+		net += d.Amount
+	}
+	return net
+}
+
+// Inflow returns the sum of the Amounts of the Deltas that are increases.
+func (ds Deltas) Inflow() int {
+	var in int
+	for _, d := range ds {
+		if d.Amount > 0 {
+			in += d.Amount
+		}
+	}
+	return in
+}
+
+// Outflow returns the sum of the Amounts of the Deltas that are decreases,
+// as a positive number.
+func (ds Deltas) Outflow() int {
+	var out int
+	for _, d := range ds {
+		if d.Amount < 0 {
+			out -= d.Amount
+		}
+	}
+	return out
+}
+
+// InRange returns the Deltas whose To time is within the given Range, as
+// decided by Range.Contains.
+func (ds Deltas) InRange(r gtime.Range) Deltas {
+	var in Deltas
+	for _, d := range ds {
+		if r.Contains(d.To) {
+			in = append(in, d)
+		}
+	}
+	return in
+}
+
+// LargestRise returns the Delta with the greatest increase.
+// If multiple Deltas have the same Amount, the first encountered is returned.
+// If there are no Deltas, an ErrNoDeltas error is returned.
+func (ds Deltas) LargestRise() (Delta, error) {
+	return ds.extreme(func(a, b int) bool { return a > b })
+}
+
+// LargestDrop returns the Delta with the greatest decrease.
+// If multiple Deltas have the same Amount, the first encountered is returned.
+// If there are no Deltas, an ErrNoDeltas error is returned.
+func (ds Deltas) LargestDrop() (Delta, error) {
+	return ds.extreme(func(a, b int) bool { return a < b })
+}
+
+func (ds Deltas) extreme(better func(a, b int) bool) (Delta, error) {
+	if len(ds) == 0 {
+		return Delta{}, errors.New(ErrNoDeltas)
+	}
+	e := ds[0]
+	for _, d := range ds[1:] {
+		if better(d.Amount, e.Amount) {
+			e = d
+		}
+	}
+	return e, nil
+}
+
+// Period holds the flow of money over a period of time, from Start
+// inclusive to End exclusive.
+type Period struct {
+	Start   time.Time
+	End     time.Time
+	Inflow  int
+	Outflow int
+	Net     int
+}
+
+// Periods aggregates the Deltas into consecutive Periods of the given
+// Interval, the first starting at start and the last being the Period that
+// contains end.
+// Each Delta is counted in the Period that contains its To time.
+// Periods returns an error if the Interval is invalid.
+func (ds Deltas) Periods(start, end time.Time, i balance.Interval) ([]Period, error) {
+	starts, err := i.Times(start, end)
+	if err != nil {
+		return nil, err
+	}
+	ps := make([]Period, len(starts))
+	for n, s := range starts {
+		ps[n] = Period{Start: s, End: i.Add(start, n+1)}
+	}
+	for _, d := range ds {
+		n := sort.Search(len(ps), func(n int) bool { return d.To.Before(ps[n].End) })
+		if n == len(ps) || d.To.Before(ps[n].Start) {
+			continue
+		}
+		if d.Amount > 0 {
+			ps[n].Inflow += d.Amount
+		} else {
+			ps[n].Outflow -= d.Amount
+		}
+		ps[n].Net += d.Amount
+	}
+	return ps, nil
+}
+
+// Monthly aggregates the Deltas into calendar month Periods, from the month
+// of the earliest Delta to the month of the latest.
+// Calendar months are taken in the location of the earliest Delta.
+func (ds Deltas) Monthly() []Period {
+	if len(ds) == 0 {
+		return nil
+	}
+	first, last := ds[0].To, ds[0].To
+	for _, d := range ds[1:] {
+		if d.To.Before(first) {
+			first = d.To
+		}
+		if d.To.After(last) {
+			last = d.To
+		}
+	}
+	start := time.Date(first.Year(), first.Month(), 1, 0, 0, 0, 0, first.Location())
+	ps, _ := ds.Periods(start, last, balance.Month)
+	return ps
+}
@@ -0,0 +1,103 @@
+package cashflow_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/glynternet/go-accounting/accountingtest"
+	"github.com/glynternet/go-accounting/balance"
+	"github.com/glynternet/go-accounting/cashflow"
+	gtime "github.com/glynternet/go-time"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNew(t *testing.T) {
+	gbp := accountingtest.NewCurrencyCode(t, "GBP")
+	ds, err := cashflow.New(balance.Balances{
+		{Date: accountingtest.Date(2020, 1, 10), Amount: 150, Currency: gbp},
+		{Date: accountingtest.Date(2020, 1, 1), Amount: 100, Currency: gbp},
+		{Date: accountingtest.Date(2020, 1, 20), Amount: 40, Currency: gbp},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, cashflow.Deltas{
+		{From: accountingtest.Date(2020, 1, 1), To: accountingtest.Date(2020, 1, 10), Amount: 50, Currency: gbp},
+		{From: accountingtest.Date(2020, 1, 10), To: accountingtest.Date(2020, 1, 20), Amount: -110, Currency: gbp},
+	}, ds)
+
+	ds, err = cashflow.New(balance.Balances{{Date: accountingtest.Date(2020, 1, 1), Amount: 100}})
+	assert.Nil(t, err)
+	assert.Empty(t, ds)
+
+	_, err = cashflow.New(balance.Balances{
+		{Date: accountingtest.Date(2020, 1, 1), Amount: 100, Currency: gbp},
+		{Date: accountingtest.Date(2020, 1, 2), Amount: 100, Currency: accountingtest.NewCurrencyCode(t, "EUR")},
+	})
+	assert.Equal(t, errors.New(balance.ErrMixedCurrencies), err)
+}
+
+func TestDeltas_Flows(t *testing.T) {
+	ds := cashflow.Deltas{
+		{To: accountingtest.Date(2020, 1, 2), Amount: 50},
+		{To: accountingtest.Date(2020, 1, 3), Amount: -110},
+		{To: accountingtest.Date(2020, 1, 4), Amount: 20},
+		{To: accountingtest.Date(2020, 1, 5), Amount: -10},
+	}
+	assert.Equal(t, 70, ds.Inflow())
+	assert.Equal(t, 120, ds.Outflow())
+	assert.Equal(t, -50, ds.Net())
+
+	rise, err := ds.LargestRise()
+	assert.Nil(t, err)
+	assert.Equal(t, ds[0], rise)
+	drop, err := ds.LargestDrop()
+	assert.Nil(t, err)
+	assert.Equal(t, ds[1], drop)
+
+	r, err := gtime.New(gtime.Start(accountingtest.Date(2020, 1, 3)), gtime.End(accountingtest.Date(2020, 1, 5)))
+	assert.Nil(t, err)
+	assert.Equal(t, cashflow.Deltas{ds[1], ds[2]}, ds.InRange(*r))
+}
+
+func TestDeltas_Empty(t *testing.T) {
+	var ds cashflow.Deltas
+	assert.Equal(t, 0, ds.Net())
+	_, err := ds.LargestRise()
+	assert.Equal(t, errors.New(cashflow.ErrNoDeltas), err)
+	_, err = ds.LargestDrop()
+	assert.Equal(t, errors.New(cashflow.ErrNoDeltas), err)
+	assert.Empty(t, ds.Monthly())
+}
+
+func TestDeltas_Periods(t *testing.T) {
+	ds := cashflow.Deltas{
+		{To: accountingtest.Date(2019, 12, 31), Amount: 1000},
+		{To: accountingtest.Date(2020, 1, 1), Amount: 50},
+		{To: accountingtest.Date(2020, 1, 7), Amount: -20},
+		{To: accountingtest.Date(2020, 1, 8), Amount: 30},
+		{To: accountingtest.Date(2020, 1, 14), Amount: -5},
+		{To: accountingtest.Date(2020, 1, 15), Amount: 1000},
+	}
+	ps, err := ds.Periods(accountingtest.Date(2020, 1, 1), accountingtest.Date(2020, 1, 14), balance.Week)
+	assert.Nil(t, err)
+	assert.Equal(t, []cashflow.Period{
+		{Start: accountingtest.Date(2020, 1, 1), End: accountingtest.Date(2020, 1, 8), Inflow: 50, Outflow: 20, Net: 30},
+		{Start: accountingtest.Date(2020, 1, 8), End: accountingtest.Date(2020, 1, 15), Inflow: 30, Outflow: 5, Net: 25},
+	}, ps)
+
+	_, err = ds.Periods(accountingtest.Date(2020, 1, 1), accountingtest.Date(2020, 1, 14), balance.Interval(0))
+	assert.Equal(t, errors.New(balance.ErrInvalidInterval), err)
+}
+
+func TestDeltas_Monthly(t *testing.T) {
+	ds := cashflow.Deltas{
+		{To: accountingtest.Date(2020, 3, 31), Amount: -40},
+		{To: accountingtest.Date(2020, 1, 15), Amount: 100},
+		{To: accountingtest.Date(2020, 1, 31), Amount: -30},
+		{To: accountingtest.Date(2020, 3, 1), Amount: 10},
+	}
+	assert.Equal(t, []cashflow.Period{
+		{Start: accountingtest.Date(2020, 1, 1), End: accountingtest.Date(2020, 2, 1), Inflow: 100, Outflow: 30, Net: 70},
+		{Start: accountingtest.Date(2020, 2, 1), End: accountingtest.Date(2020, 3, 1)},
+		{Start: accountingtest.Date(2020, 3, 1), End: accountingtest.Date(2020, 4, 1), Inflow: 10, Outflow: 40, Net: -30},
+	}, ds.Monthly())
+}
@@ -0,0 +1,34 @@
+package csvimport
+
+import (
+	"bytes"
+	"fmt"
+)
+
+// RowError describes why a single row of a statement could not be imported.
+// Line is the line number of the statement that the row starts on, counting from 1.
+type RowError struct {
+	Line int
+	Err  error
+}
+
+// Error ensures that RowError adheres to the error interface.
+func (e RowError) Error() string {
+	return fmt.Sprintf("line %d: %s", e.Line, e.Err)
+}
+
+// RowErrors holds the RowError of every row of a statement that could not be imported.
+type RowErrors []RowError
+
+// Error ensures that RowErrors adheres to the error interface.
+func (e RowErrors) Error() string {
+	var errorString bytes.Buffer
+	errorString.WriteString("RowErrors: ")
+	for i, re := range e {
+		errorString.WriteString(re.Error())
+		if i < len(e)-1 {
+			errorString.WriteString("; ")
+		}
+	}
+	return errorString.String()
+}
@@ -0,0 +1,128 @@
+// Package csvimport imports bank statements in CSV format as Balances of an Account.
+package csvimport
+
+import (
+	"encoding/csv"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/glynternet/go-accounting/account"
+	"github.com/glynternet/go-accounting/balance"
+	"github.com/glynternet/go-accounting/internal/amount"
+	"github.com/pkg/errors"
+)
+
+// Various error messages describing possible errors when importing a CSV statement.
+const (
+	ErrInvalidColumn = "invalid column index"
+	ErrEmptyLayout   = "empty date layout"
+	ErrMissingColumn = "row has too few columns"
+)
+
+// NewImporter creates a new Importer that reads the Date of each Balance from
+// the dateColumn, parsed with the dateLayout as used by time.Parse, and the
+// Amount of each Balance from the amountColumn. Columns are indexed from zero.
+// By default, amounts are read with '.' as the decimal separator and two
+// decimal places, and a negative amount is read as a negative Balance.
+func NewImporter(dateColumn int, dateLayout string, amountColumn int, os ...Option) (*Importer, error) {
+	if dateColumn < 0 || amountColumn < 0 {
+		return nil, errors.New(ErrInvalidColumn)
+	}
+	if strings.TrimSpace(dateLayout) == "" {
+		return nil, errors.New(ErrEmptyLayout)
+	}
+	i := &Importer{
+		dateColumn:       dateColumn,
+		dateLayout:       dateLayout,
+		amountColumn:     amountColumn,
+		decimalSeparator: '.',
+		decimalPlaces:    2,
+		sign:             1,
+		comma:            ',',
+		location:         time.UTC,
+	}
+	for _, o := range os {
+		if o == nil {
+			continue
+		}
+		if err := o(i); err != nil {
+			return nil, err
+		}
+	}
+	return i, nil
+}
+
+// Importer holds the column mapping used to read a CSV statement.
+type Importer struct {
+	dateColumn       int
+	dateLayout       string
+	amountColumn     int
+	decimalSeparator rune
+	decimalPlaces    int
+	sign             int
+	comma            rune
+	skipRows         int
+	location         *time.Location
+}
+
+// Import reads a CSV statement, returning a Balance in the currency of the
+// Account for each row.
+// Each Balance is validated through Account.ValidateBalance, so rows with a
+// date outside of the TimeRange of the Account are rejected.
+// Rows that cannot be read are not included in the returned Balances, and are
+// reported in a RowErrors error alongside the Balances of all valid rows.
+// Any other error will cause Import to return immediately with no Balances.
+func (i Importer) Import(r io.Reader, a account.Account) (balance.Balances, error) {
+	cr := csv.NewReader(r)
+	cr.Comma = i.comma
+	cr.FieldsPerRecord = -1
+	var bs balance.Balances
+	var rowErrs RowErrors
+	for row := 0; ; row++ {
+		record, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		if pe, ok := err.(*csv.ParseError); ok {
+			rowErrs = append(rowErrs, RowError{Line: pe.StartLine, Err: pe.Err})
+			continue
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "reading CSV")
+		}
+		if row < i.skipRows {
+			continue
+		}
+		line, _ := cr.FieldPos(0)
+		b, err := i.parse(record)
+		if err == nil {
+			err = a.ValidateBalance(b)
+		}
+		if err != nil {
+			rowErrs = append(rowErrs, RowError{Line: line, Err: err})
+			continue
+		}
+		b.Currency = a.CurrencyCode()
+		bs = append(bs, b)
+	}
+	if len(rowErrs) > 0 {
+		return bs, rowErrs
+	}
+	return bs, nil
+}
+
+func (i Importer) parse(record []string) (balance.Balance, error) {
+	if i.dateColumn >= len(record) || i.amountColumn >= len(record) {
+		return balance.Balance{}, errors.New(ErrMissingColumn)
+	}
+	date, err := time.ParseInLocation(i.dateLayout, strings.TrimSpace(record[i.dateColumn]), i.location)
+	if err != nil {
+		return balance.Balance{}, errors.Wrap(err, "parsing date")
+	}
+	a, err := amount.Parse(record[i.amountColumn], i.decimalSeparator, i.decimalPlaces)
+	if err != nil {
+		return balance.Balance{}, errors.Wrap(err, "parsing amount")
+	}
+	return balance.Balance{Date: date, Amount: i.sign * a}, nil
+}
@@ -0,0 +1,112 @@
+package csvimport_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/glynternet/go-accounting/account"
+	"github.com/glynternet/go-accounting/accountingtest"
+	"github.com/glynternet/go-accounting/balance"
+	"github.com/glynternet/go-accounting/csvimport"
+	"github.com/glynternet/go-money/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewImporter(t *testing.T) {
+	_, err := csvimport.NewImporter(0, "2006-01-02", 1)
+	assert.Nil(t, err)
+
+	for _, test := range []struct {
+		name         string
+		dateColumn   int
+		layout       string
+		amountColumn int
+		options      []csvimport.Option
+	}{
+		{name: "negative date column", dateColumn: -1, layout: "2006", amountColumn: 1},
+		{name: "negative amount column", layout: "2006", amountColumn: -1},
+		{name: "empty layout", layout: " ", amountColumn: 1},
+		{name: "invalid separator", layout: "2006", amountColumn: 1, options: []csvimport.Option{csvimport.DecimalSeparator('x')}},
+		{name: "invalid places", layout: "2006", amountColumn: 1, options: []csvimport.Option{csvimport.DecimalPlaces(-1)}},
+		{name: "invalid skip rows", layout: "2006", amountColumn: 1, options: []csvimport.Option{csvimport.SkipRows(-1)}},
+		{name: "nil location", layout: "2006", amountColumn: 1, options: []csvimport.Option{csvimport.Location(nil)}},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			i, err := csvimport.NewImporter(test.dateColumn, test.layout, test.amountColumn, test.options...)
+			assert.Error(t, err)
+			assert.Nil(t, i)
+		})
+	}
+}
+
+func TestImporter_Import(t *testing.T) {
+	gbp := accountingtest.NewCurrencyCode(t, "GBP")
+	a := accountingtest.NewAccount(t, "Current", gbp, accountingtest.Date(2018, 1, 1), account.CloseTime(accountingtest.Date(2018, 12, 31)))
+
+	const statement = `Date,Description,Balance
+01/02/2018,Salary,"2,500.00"
+03/02/2018,Rent,1300.5
+04/02/2018,Shop,not a number
+31/12/2017,Interest,1.00
+05/02/2018
+06/02/2018,Refund,(12.34)
+`
+	i, err := csvimport.NewImporter(0, "02/01/2006", 2, csvimport.SkipRows(1))
+	common.FatalIfError(t, err, "creating Importer")
+	bs, err := i.Import(strings.NewReader(statement), *a)
+
+	assert.Equal(t, balance.Balances{
+		{Date: accountingtest.Date(2018, 2, 1), Amount: 250000, Currency: gbp},
+		{Date: accountingtest.Date(2018, 2, 3), Amount: 130050, Currency: gbp},
+		{Date: accountingtest.Date(2018, 2, 6), Amount: -1234, Currency: gbp},
+	}, bs)
+
+	rowErrs, ok := err.(csvimport.RowErrors)
+	if !assert.True(t, ok, "expected RowErrors but got %T: %v", err, err) {
+		return
+	}
+	var lines []int
+	for _, re := range rowErrs {
+		lines = append(lines, re.Line)
+	}
+	assert.Equal(t, []int{4, 5, 6}, lines)
+	assert.IsType(t, balance.DateOutOfAccountTimeRange{}, rowErrs[1].Err)
+	assert.Equal(t, errors.New(csvimport.ErrMissingColumn).Error(), rowErrs[2].Err.Error())
+}
+
+func TestImporter_Import_Options(t *testing.T) {
+	eur := accountingtest.NewCurrencyCode(t, "EUR")
+	a := accountingtest.NewAccount(t, "Card", eur, accountingtest.Date(2018, 1, 1))
+	location := time.FixedZone("CET", 60*60)
+
+	const statement = "2018-02-01;1.234,56\n2018-02-02;-10,5\n"
+	i, err := csvimport.NewImporter(0, "2006-01-02", 1,
+		csvimport.Comma(';'),
+		csvimport.DecimalSeparator(','),
+		csvimport.Inverted(),
+		csvimport.Location(location),
+	)
+	common.FatalIfError(t, err, "creating Importer")
+	bs, err := i.Import(strings.NewReader(statement), *a)
+	assert.Nil(t, err)
+	assert.Equal(t, balance.Balances{
+		{Date: time.Date(2018, 2, 1, 0, 0, 0, 0, location), Amount: -123456, Currency: eur},
+		{Date: time.Date(2018, 2, 2, 0, 0, 0, 0, location), Amount: 1050, Currency: eur},
+	}, bs)
+
+	i, err = csvimport.NewImporter(0, "2006-01-02", 1, csvimport.DecimalPlaces(0))
+	common.FatalIfError(t, err, "creating Importer")
+	bs, err = i.Import(strings.NewReader("2018-02-01,1500\n"), *a)
+	assert.Nil(t, err)
+	assert.Equal(t, 1500, bs[0].Amount)
+}
+
+func TestRowErrors_Error(t *testing.T) {
+	err := csvimport.RowErrors{
+		{Line: 2, Err: errors.New("first")},
+		{Line: 5, Err: errors.New("second")},
+	}
+	assert.Equal(t, "RowErrors: line 2: first; line 5: second", err.Error())
+}
@@ -0,0 +1,76 @@
+package csvimport
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Option is a function that takes a pointer to an Importer returning an error.
+// The idea of Option is to alter an Importer object
+type Option func(*Importer) error
+
+// DecimalSeparator is an Option that sets the rune separating the whole and
+// fractional parts of amounts.
+func DecimalSeparator(r rune) Option {
+	return func(i *Importer) error {
+		if r != '.' && r != ',' {
+			return errors.Errorf("unsupported decimal separator %q", r)
+		}
+		i.decimalSeparator = r
+		return nil
+	}
+}
+
+// DecimalPlaces is an Option that sets the number of decimal places in a major
+// unit of the currency of the statement.
+func DecimalPlaces(n int) Option {
+	return func(i *Importer) error {
+		if n < 0 {
+			return errors.Errorf("invalid decimal places %d", n)
+		}
+		i.decimalPlaces = n
+		return nil
+	}
+}
+
+// Inverted is an Option that negates every amount read, for statements where
+// money owed is shown as a positive amount.
+func Inverted() Option {
+	return func(i *Importer) error {
+		i.sign = -1
+		return nil
+	}
+}
+
+// Comma is an Option that sets the field delimiter of the statement.
+func Comma(r rune) Option {
+	return func(i *Importer) error {
+		i.comma = r
+		return nil
+	}
+}
+
+// SkipRows is an Option that sets the number of rows, such as headers, to
+// skip at the start of the statement.
+func SkipRows(n int) Option {
+	return func(i *Importer) error {
+		if n < 0 {
+			return errors.Errorf("invalid rows to skip %d", n)
+		}
+		i.skipRows = n
+		return nil
+	}
+}
+
+// Location is an Option that sets the Location in which dates without a time
+// zone are interpreted. By default, dates are interpreted as UTC.
+func Location(l *time.Location) Option {
+	return func(i *Importer) error {
+		if l == nil {
+			return errors.New("nil Location")
+		}
+		i.location = l
+		return nil
+	}
+}
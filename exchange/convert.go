@@ -0,0 +1,58 @@
+package exchange
+
+import (
+	"math"
+
+	"github.com/glynternet/go-accounting/account"
+	"github.com/glynternet/go-accounting/balance"
+	"github.com/glynternet/go-money/currency"
+	"github.com/pkg/errors"
+)
+
+// Rounding converts a converted amount into a whole number of the minor units of a currency.
+type Rounding func(float64) int
+
+// Various Rounding functions that can be used when converting Balances.
+var (
+	// HalfAwayFromZero rounds to the nearest integer, rounding half away from zero.
+	HalfAwayFromZero Rounding = func(f float64) int { return int(math.Round(f)) }
+	// HalfEven rounds to the nearest integer, rounding half to even.
+	HalfEven Rounding = func(f float64) int { return int(math.RoundToEven(f)) }
+	// Floor rounds down to the nearest integer.
+	Floor Rounding = func(f float64) int { return int(math.Floor(f)) }
+	// Ceil rounds up to the nearest integer.
+	Ceil Rounding = func(f float64) int { return int(math.Ceil(f)) }
+	// Truncate rounds towards zero to the nearest integer.
+	Truncate Rounding = func(f float64) int { return int(math.Trunc(f)) }
+)
+
+// Convert converts each Balance of an Account from the currency of the Account
+// into another currency, using the Rate of the Table at the Date of each
+// Balance.
+// Each Balance is first validated through Account.ValidateBalance.
+// Converted amounts are rounded using the given Rounding. A nil Rounding will
+// use HalfAwayFromZero.
+// Convert returns an error if any Balance is invalid for the Account or if no
+// Rate can be found for any Balance.
+func (t Table) Convert(a account.Account, bs balance.Balances, to currency.Code, r Rounding) (balance.Balances, error) {
+	if r == nil {
+		r = HalfAwayFromZero
+	}
+	p := Pair{From: a.CurrencyCode(), To: to}
+	converted := make(balance.Balances, 0, len(bs))
+	for _, b := range bs {
+		if err := a.ValidateBalance(b); err != nil {
+			return nil, err
+		}
+		rate, err := t.At(p, b.Date)
+		if err != nil {
+			return nil, errors.Wrapf(err, "getting Rate for %s to %s at %s", p.From, p.To, b.Date)
+		}
+		converted = append(converted, balance.Balance{
+			Date:     b.Date,
+			Amount:   r(float64(b.Amount) * rate.Value),
+			Currency: to,
+		})
+	}
+	return converted, nil
+}
@@ -0,0 +1,101 @@
+package exchange_test
+
+import (
+	"testing"
+
+	"github.com/glynternet/go-accounting/accountingtest"
+	"github.com/glynternet/go-accounting/balance"
+	"github.com/glynternet/go-accounting/exchange"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRoundings(t *testing.T) {
+	for _, test := range []struct {
+		name string
+		exchange.Rounding
+		in       []float64
+		expected []int
+	}{
+		{
+			name:     "half away from zero",
+			Rounding: exchange.HalfAwayFromZero,
+			in:       []float64{1.5, 2.5, -1.5, 1.4},
+			expected: []int{2, 3, -2, 1},
+		},
+		{
+			name:     "half even",
+			Rounding: exchange.HalfEven,
+			in:       []float64{1.5, 2.5, -1.5, 1.4},
+			expected: []int{2, 2, -2, 1},
+		},
+		{
+			name:     "floor",
+			Rounding: exchange.Floor,
+			in:       []float64{1.5, -1.5},
+			expected: []int{1, -2},
+		},
+		{
+			name:     "ceil",
+			Rounding: exchange.Ceil,
+			in:       []float64{1.5, -1.5},
+			expected: []int{2, -1},
+		},
+		{
+			name:     "truncate",
+			Rounding: exchange.Truncate,
+			in:       []float64{1.5, -1.5},
+			expected: []int{1, -1},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			for i, f := range test.in {
+				assert.Equal(t, test.expected[i], test.Rounding(f), "rounding %f", f)
+			}
+		})
+	}
+}
+
+func TestTable_Convert(t *testing.T) {
+	gbp := accountingtest.NewCurrencyCode(t, "GBP")
+	eur := accountingtest.NewCurrencyCode(t, "EUR")
+	a := accountingtest.NewAccount(t, "A", gbp, newTestDate(2000))
+
+	table := exchange.NewTable()
+	assert.Nil(t, table.Add(exchange.Pair{From: gbp, To: eur}, exchange.Rate{Date: newTestDate(2000), Value: 1.125}))
+	assert.Nil(t, table.Add(exchange.Pair{From: gbp, To: eur}, exchange.Rate{Date: newTestDate(2002), Value: 1.5}))
+
+	bs := balance.Balances{
+		{Date: newTestDate(2000), Amount: 100},
+		{Date: newTestDate(2001), Amount: 4, Currency: gbp},
+		{Date: newTestDate(2002), Amount: -3},
+	}
+
+	converted, err := table.Convert(*a, bs, eur, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, balance.Balances{
+		{Date: newTestDate(2000), Amount: 113, Currency: eur},
+		{Date: newTestDate(2001), Amount: 5, Currency: eur},
+		{Date: newTestDate(2002), Amount: -5, Currency: eur},
+	}, converted)
+
+	converted, err = table.Convert(*a, bs, eur, exchange.Floor)
+	assert.Nil(t, err)
+	assert.Equal(t, balance.Balances{
+		{Date: newTestDate(2000), Amount: 112, Currency: eur},
+		{Date: newTestDate(2001), Amount: 4, Currency: eur},
+		{Date: newTestDate(2002), Amount: -5, Currency: eur},
+	}, converted)
+
+	converted, err = table.Convert(*a, bs, gbp, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, 100, converted[0].Amount)
+
+	_, err = table.Convert(*a, balance.Balances{{Date: newTestDate(1999)}}, eur, nil)
+	assert.IsType(t, balance.DateOutOfAccountTimeRange{}, err)
+
+	_, err = table.Convert(*a, balance.Balances{{Date: newTestDate(2000), Currency: eur}}, eur, nil)
+	assert.IsType(t, balance.CurrencyMismatch{}, err)
+
+	_, err = table.Convert(*a, bs, accountingtest.NewCurrencyCode(t, "USD"), nil)
+	assert.Error(t, err)
+}
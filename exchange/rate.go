@@ -0,0 +1,88 @@
+package exchange
+
+import (
+	"errors"
+	"time"
+
+	"github.com/glynternet/go-accounting/internal/timeseries"
+	"github.com/glynternet/go-money/currency"
+)
+
+// Various error messages describing possible errors when using a Table.
+const (
+	ErrNoRate      = "no Rate"
+	ErrInvalidRate = "invalid Rate"
+)
+
+// Pair identifies the currencies that a Rate converts between.
+type Pair struct {
+	From currency.Code
+	To   currency.Code
+}
+
+// Inverse returns the Pair that converts in the opposite direction.
+func (p Pair) Inverse() Pair {
+	return Pair{From: p.To, To: p.From}
+}
+
+// Rate holds the value of one unit of the From currency of a Pair in units of
+// the To currency, from a given Date.
+type Rate struct {
+	Date  time.Time
+	Value float64
+}
+
+// NewTable creates a new, empty Table.
+func NewTable() *Table {
+	return &Table{rates: make(map[Pair][]Rate)}
+}
+
+// Table holds a time-indexed series of Rates for each Pair of currencies.
+type Table struct {
+	rates map[Pair][]Rate
+}
+
+// Add adds a Rate for a given Pair to the Table.
+// Add returns an error if the Value of the Rate is not greater than zero.
+func (t *Table) Add(p Pair, r Rate) error {
+	if r.Value <= 0 {
+		return errors.New(ErrInvalidRate)
+	}
+	if t.rates == nil {
+		t.rates = make(map[Pair][]Rate)
+	}
+	t.rates[p] = append(t.rates[p], r)
+	return nil
+}
+
+// At returns the latest Rate for a Pair that is at or before a given time,
+// using the same semantics as Balances.AtTime: if multiple Rates have the same
+// Date that is the latest, the Rate that was added last will be returned.
+// Rates held for the inverse of the Pair are also considered, inverted, and
+// whichever of the two Rates has the later Date is returned, so that a stale
+// Rate in one direction never hides a recent Rate in the other. When both
+// have the same Date, the Rate held for the Pair itself is returned.
+// A Pair that converts a currency into itself always has a Rate of 1.
+// If no appropriate Rate can be found, an ErrNoRate error is returned.
+func (t Table) At(p Pair, at time.Time) (Rate, error) {
+	if p.From == p.To {
+		return Rate{Date: at, Value: 1}, nil
+	}
+	direct, hasDirect := latest(t.rates[p], at)
+	inverse, hasInverse := latest(t.rates[p.Inverse()], at)
+	switch {
+	case hasInverse && (!hasDirect || inverse.Date.After(direct.Date)):
+		return Rate{Date: inverse.Date, Value: 1 / inverse.Value}, nil
+	case hasDirect:
+		return direct, nil
+	}
+	return Rate{}, errors.New(ErrNoRate)
+}
+
+func latest(rs []Rate, at time.Time) (Rate, bool) {
+	i := timeseries.LatestAt(len(rs), func(i int) time.Time { return rs[i].Date }, at)
+	if i < 0 {
+		return Rate{}, false
+	}
+	return rs[i], true
+}
@@ -0,0 +1,117 @@
+package exchange_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/glynternet/go-accounting/accountingtest"
+	"github.com/glynternet/go-accounting/exchange"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTable_Add(t *testing.T) {
+	var table exchange.Table
+	p := exchange.Pair{From: accountingtest.NewCurrencyCode(t, "GBP"), To: accountingtest.NewCurrencyCode(t, "EUR")}
+	assert.Nil(t, table.Add(p, exchange.Rate{Date: newTestDate(2000), Value: 1.1}))
+	for _, v := range []float64{0, -1} {
+		assert.Equal(t, errors.New(exchange.ErrInvalidRate), table.Add(p, exchange.Rate{Value: v}))
+	}
+}
+
+func TestTable_At(t *testing.T) {
+	gbp := accountingtest.NewCurrencyCode(t, "GBP")
+	eur := accountingtest.NewCurrencyCode(t, "EUR")
+	usd := accountingtest.NewCurrencyCode(t, "USD")
+	gbpEur := exchange.Pair{From: gbp, To: eur}
+	eurUsd := exchange.Pair{From: eur, To: usd}
+
+	table := exchange.NewTable()
+	for _, r := range []struct {
+		exchange.Pair
+		exchange.Rate
+	}{
+		{Pair: gbpEur, Rate: exchange.Rate{Date: newTestDate(2001), Value: 1.2}},
+		{Pair: gbpEur, Rate: exchange.Rate{Date: newTestDate(2000), Value: 1.1}},
+		{Pair: gbpEur, Rate: exchange.Rate{Date: newTestDate(2001), Value: 1.25}},
+		{Pair: gbpEur, Rate: exchange.Rate{Date: newTestDate(2003), Value: 1.3}},
+		{Pair: eurUsd, Rate: exchange.Rate{Date: newTestDate(2000), Value: 2}},
+		{Pair: gbpEur.Inverse(), Rate: exchange.Rate{Date: newTestDate(2002), Value: 0.5}},
+		{Pair: gbpEur.Inverse(), Rate: exchange.Rate{Date: newTestDate(2003), Value: 0.8}},
+	} {
+		assert.Nil(t, table.Add(r.Pair, r.Rate))
+	}
+
+	for _, test := range []struct {
+		name string
+		exchange.Pair
+		at       time.Time
+		expected exchange.Rate
+		err      error
+	}{
+		{
+			name: "before any rate",
+			Pair: gbpEur,
+			at:   newTestDate(1999),
+			err:  errors.New(exchange.ErrNoRate),
+		},
+		{
+			name:     "at rate",
+			Pair:     gbpEur,
+			at:       newTestDate(2000),
+			expected: exchange.Rate{Date: newTestDate(2000), Value: 1.1},
+		},
+		{
+			name:     "duplicate dates uses last added",
+			Pair:     gbpEur,
+			at:       newTestDate(2001).Add(time.Hour),
+			expected: exchange.Rate{Date: newTestDate(2001), Value: 1.25},
+		},
+		{
+			name:     "later inverse rate beats stale direct rate",
+			Pair:     gbpEur,
+			at:       newTestDate(2002),
+			expected: exchange.Rate{Date: newTestDate(2002), Value: 2},
+		},
+		{
+			name:     "later direct rate beats stale inverse rate",
+			Pair:     gbpEur.Inverse(),
+			at:       newTestDate(2002),
+			expected: exchange.Rate{Date: newTestDate(2002), Value: 0.5},
+		},
+		{
+			name:     "direct rate preferred on equal dates",
+			Pair:     gbpEur,
+			at:       newTestDate(2010),
+			expected: exchange.Rate{Date: newTestDate(2003), Value: 1.3},
+		},
+		{
+			name:     "inverse",
+			Pair:     eurUsd.Inverse(),
+			at:       newTestDate(2010),
+			expected: exchange.Rate{Date: newTestDate(2000), Value: 0.5},
+		},
+		{
+			name:     "same currency",
+			Pair:     exchange.Pair{From: usd, To: usd},
+			at:       newTestDate(2010),
+			expected: exchange.Rate{Date: newTestDate(2010), Value: 1},
+		},
+		{
+			name: "unknown pair",
+			Pair: exchange.Pair{From: gbp, To: usd},
+			at:   newTestDate(2010),
+			err:  errors.New(exchange.ErrNoRate),
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			r, err := table.At(test.Pair, test.at)
+			assert.Equal(t, test.err, err)
+			assert.Equal(t, test.expected, r)
+		})
+	}
+}
+
+func newTestDate(year int) time.Time {
+	return time.Date(year, 1, 1, 1, 1, 1, 1, time.UTC)
+}
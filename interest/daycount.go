@@ -0,0 +1,59 @@
+package interest
+
+import "time"
+
+// DayCount is a convention for measuring a length of time as a fraction of a
+// year, when calculating interest from an annual rate.
+type DayCount int
+
+// The various DayCount conventions that can be used to calculate interest.
+const (
+	// Actual365 counts the actual time elapsed, over a year of 365 days.
+	Actual365 DayCount = iota + 1
+	// Thirty360 counts each month as having 30 days, over a year of 360 days,
+	// using the 30/360 bond basis. Only whole days are counted.
+	Thirty360
+)
+
+var dayCountNames = map[DayCount]string{
+	Actual365: "ACT/365",
+	Thirty360: "30/360",
+}
+
+// String returns the name of a DayCount.
+func (dc DayCount) String() string {
+	if n, ok := dayCountNames[dc]; ok {
+		return n
+	}
+	return "invalid"
+}
+
+// Valid returns true if the DayCount is one of the known DayCounts.
+func (dc DayCount) Valid() bool {
+	_, ok := dayCountNames[dc]
+	return ok
+}
+
+// YearFraction returns the length of time from one time to another as a
+// fraction of a year, according to the DayCount.
+func (dc DayCount) YearFraction(from, to time.Time) float64 {
+	switch dc {
+	case Actual365:
+		return to.Sub(from).Hours() / 24 / 365
+	case Thirty360:
+		return float64(thirty360Days(from, to)) / 360
+	}
+	return 0
+}
+
+func thirty360Days(from, to time.Time) int {
+	y1, m1, d1 := from.Date()
+	y2, m2, d2 := to.Date()
+	if d1 == 31 {
+		d1 = 30
+	}
+	if d2 == 31 && d1 == 30 {
+		d2 = 30
+	}
+	return 360*(y2-y1) + 30*int(m2-m1) + d2 - d1
+}
@@ -0,0 +1,38 @@
+package interest_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/glynternet/go-accounting/accountingtest"
+	"github.com/glynternet/go-accounting/interest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDayCount_YearFraction(t *testing.T) {
+	for _, test := range []struct {
+		interest.DayCount
+		from, to time.Time
+		expected float64
+	}{
+		{DayCount: interest.Actual365, from: accountingtest.Date(2019, 1, 1), to: accountingtest.Date(2020, 1, 1), expected: 1},
+		{DayCount: interest.Actual365, from: accountingtest.Date(2020, 1, 1), to: accountingtest.Date(2021, 1, 1), expected: 366.0 / 365},
+		{DayCount: interest.Actual365, from: accountingtest.Date(2020, 1, 1), to: accountingtest.Date(2020, 1, 1).Add(12 * time.Hour), expected: 0.5 / 365},
+		{DayCount: interest.Thirty360, from: accountingtest.Date(2020, 1, 1), to: accountingtest.Date(2021, 1, 1), expected: 1},
+		{DayCount: interest.Thirty360, from: accountingtest.Date(2020, 1, 31), to: accountingtest.Date(2020, 2, 1), expected: 1.0 / 360},
+		{DayCount: interest.Thirty360, from: accountingtest.Date(2020, 1, 30), to: accountingtest.Date(2020, 1, 31), expected: 0},
+		{DayCount: interest.Thirty360, from: accountingtest.Date(2019, 2, 28), to: accountingtest.Date(2019, 3, 1), expected: 3.0 / 360},
+		{DayCount: interest.Thirty360, from: accountingtest.Date(2020, 1, 15), to: accountingtest.Date(2020, 3, 31), expected: 76.0 / 360},
+		{DayCount: interest.DayCount(0), from: accountingtest.Date(2020, 1, 1), to: accountingtest.Date(2021, 1, 1), expected: 0},
+	} {
+		assert.InDelta(t, test.expected, test.YearFraction(test.from, test.to), 1e-12, "%s %s %s", test.DayCount, test.from, test.to)
+	}
+}
+
+func TestDayCount_Thirty360Month(t *testing.T) {
+	var total float64
+	for d := accountingtest.Date(2020, 1, 1); d.Before(accountingtest.Date(2020, 2, 1)); d = d.AddDate(0, 0, 1) {
+		total += interest.Thirty360.YearFraction(d, d.AddDate(0, 0, 1))
+	}
+	assert.InDelta(t, 30.0/360, total, 1e-12)
+}
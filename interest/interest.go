@@ -0,0 +1,203 @@
+// Package interest calculates the interest accrued on the Balances of an
+// Account, according to a Schedule of Rates.
+package interest
+
+import (
+	"errors"
+	"time"
+
+	"github.com/glynternet/go-accounting/account"
+	"github.com/glynternet/go-accounting/balance"
+	"github.com/glynternet/go-accounting/exchange"
+	pkgerrors "github.com/pkg/errors"
+)
+
+// Various error messages describing possible errors when creating a Calculator.
+const (
+	ErrInvalidCompounding = "invalid Compounding"
+	ErrInvalidDayCount    = "invalid DayCount"
+	ErrNilRounding        = "nil Rounding"
+)
+
+// Compounding describes how often accrued interest is added to the amount
+// that interest is calculated on.
+type Compounding int
+
+// The various Compoundings that can be used to calculate interest.
+const (
+	// Simple interest is calculated on the Balance alone and is never
+	// compounded.
+	Simple Compounding = iota + 1
+	// Daily compounding adds the interest accrued each day to the amount that
+	// interest is calculated on from the following day.
+	Daily
+	// Monthly compounding adds the interest accrued in each calendar month to
+	// the amount that interest is calculated on from the following month.
+	Monthly
+)
+
+var compoundingNames = map[Compounding]string{
+	Simple:  "simple",
+	Daily:   "daily",
+	Monthly: "monthly",
+}
+
+// String returns the name of a Compounding.
+func (c Compounding) String() string {
+	if n, ok := compoundingNames[c]; ok {
+		return n
+	}
+	return "invalid"
+}
+
+// Valid returns true if the Compounding is one of the known Compoundings.
+func (c Compounding) Valid() bool {
+	_, ok := compoundingNames[c]
+	return ok
+}
+
+// Option is a function that configures a Calculator.
+type Option func(*Calculator) error
+
+// Compounded sets the Compounding used by a Calculator.
+func Compounded(co Compounding) Option {
+	return func(c *Calculator) error {
+		if !co.Valid() {
+			return errors.New(ErrInvalidCompounding)
+		}
+		c.compounding = co
+		return nil
+	}
+}
+
+// Convention sets the DayCount used by a Calculator.
+func Convention(dc DayCount) Option {
+	return func(c *Calculator) error {
+		if !dc.Valid() {
+			return errors.New(ErrInvalidDayCount)
+		}
+		c.dayCount = dc
+		return nil
+	}
+}
+
+// Rounded sets the Rounding used by a Calculator to give whole amounts of
+// accrued interest.
+func Rounded(r exchange.Rounding) Option {
+	return func(c *Calculator) error {
+		if r == nil {
+			return errors.New(ErrNilRounding)
+		}
+		c.rounding = r
+		return nil
+	}
+}
+
+// New creates a new Calculator that uses the Rates of the given Schedule.
+// By default, a Calculator calculates Simple interest using the Actual365
+// DayCount, rounding HalfAwayFromZero.
+func New(s Schedule, os ...Option) (*Calculator, error) {
+	c := &Calculator{
+		schedule:    append(Schedule(nil), s...),
+		compounding: Simple,
+		dayCount:    Actual365,
+		rounding:    exchange.HalfAwayFromZero,
+	}
+	for _, o := range os {
+		if err := o(c); err != nil {
+			return nil, err
+		}
+	}
+	return c, nil
+}
+
+// Calculator calculates the interest accrued on Balances.
+type Calculator struct {
+	schedule    Schedule
+	compounding Compounding
+	dayCount    DayCount
+	rounding    exchange.Rounding
+}
+
+// Accrue returns the interest accrued on the Balances of an Account from start
+// to end, with a Balance for each period of the given Interval from start.
+// Each returned Balance is dated at the end of its period, which is end itself
+// for a final, partial period, and has the amount of interest accrued over
+// that period in the currency of the Account.
+// Interest is accrued a day at a time, on the Amount given by AtTime at the
+// start of each day plus any interest compounded so far, at the Rate that
+// applies at the start of the day. A day with no Balance accrues no interest.
+// Amounts are rounded so that the total of the returned Balances is the
+// rounded total of the interest accrued, rather than the total of each
+// rounded amount.
+// Accrual is limited to the lifetime of the Account. Accrue returns an error
+// if any Balance is invalid for the Account, if the Interval is invalid or if
+// no Rate applies to a day.
+func (c Calculator) Accrue(a account.Account, bs balance.Balances, start, end time.Time, i balance.Interval) (balance.Balances, error) {
+	if !i.Valid() {
+		return nil, errors.New(balance.ErrInvalidInterval)
+	}
+	for _, b := range bs {
+		if err := a.ValidateBalance(b); err != nil {
+			return nil, err
+		}
+	}
+	if start.Before(a.Opened()) {
+		start = a.Opened()
+	}
+	if a.Closed().Valid && end.After(a.Closed().Time) {
+		end = a.Closed().Time
+	}
+	if !end.After(start) {
+		return nil, nil
+	}
+
+	sorted := balance.NewSorted(bs)
+	var accrued, compounded, pending float64
+	var rounded int
+	var accruals balance.Balances
+	periodEnd := start
+	for n, day := 1, start; day.Before(end); {
+		if !day.Before(periodEnd) {
+			periodEnd = i.Add(start, n)
+			n++
+			if periodEnd.After(end) {
+				periodEnd = end
+			}
+		}
+		next := day.AddDate(0, 0, 1)
+		if next.After(periodEnd) {
+			next = periodEnd
+		}
+		rate, err := c.schedule.At(day)
+		if err != nil {
+			return nil, pkgerrors.Wrapf(err, "getting Rate at %s", day)
+		}
+		var interest float64
+		if b, err := sorted.AtTime(day); err == nil {
+			interest = (float64(b.Amount) + compounded) * rate.Annual * c.dayCount.YearFraction(day, next)
+		}
+		accrued += interest
+		switch c.compounding {
+		case Daily:
+			compounded += interest
+		case Monthly:
+			pending += interest
+			if next.Month() != day.Month() {
+				compounded += pending
+				pending = 0
+			}
+		}
+		day = next
+		if day.Equal(periodEnd) {
+			total := c.rounding(accrued)
+			accruals = append(accruals, balance.Balance{
+				Date:     day,
+				Amount:   total - rounded,
+				Currency: a.CurrencyCode(),
+			})
+			rounded = total
+		}
+	}
+	return accruals, nil
+}
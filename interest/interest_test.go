@@ -0,0 +1,119 @@
+package interest_test
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/glynternet/go-accounting/account"
+	"github.com/glynternet/go-accounting/accountingtest"
+	"github.com/glynternet/go-accounting/balance"
+	"github.com/glynternet/go-accounting/exchange"
+	"github.com/glynternet/go-accounting/interest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNew(t *testing.T) {
+	for _, test := range []struct {
+		name string
+		interest.Option
+		err error
+	}{
+		{name: "compounding", Option: interest.Compounded(interest.Daily)},
+		{name: "invalid compounding", Option: interest.Compounded(interest.Compounding(0)), err: errors.New(interest.ErrInvalidCompounding)},
+		{name: "day count", Option: interest.Convention(interest.Thirty360)},
+		{name: "invalid day count", Option: interest.Convention(interest.DayCount(0)), err: errors.New(interest.ErrInvalidDayCount)},
+		{name: "rounding", Option: interest.Rounded(exchange.Floor)},
+		{name: "nil rounding", Option: interest.Rounded(nil), err: errors.New(interest.ErrNilRounding)},
+	} {
+		c, err := interest.New(nil, test.Option)
+		assert.Equal(t, test.err, err, test.name)
+		assert.Equal(t, test.err == nil, c != nil, test.name)
+	}
+}
+
+func TestCalculator_Accrue_Simple(t *testing.T) {
+	gbp := accountingtest.NewCurrencyCode(t, "GBP")
+	a := *accountingtest.NewAccount(t, "Savings", gbp, accountingtest.Date(2020, 1, 1))
+	bs := balance.Balances{
+		{Date: accountingtest.Date(2020, 1, 1), Amount: 1000000},
+		{Date: accountingtest.Date(2020, 1, 11), Amount: 2000000},
+	}
+	c, err := interest.New(interest.Schedule{
+		{Date: accountingtest.Date(2020, 1, 1), Annual: 0.0365},
+		{Date: accountingtest.Date(2020, 1, 16), Annual: 0.073},
+	})
+	assert.Nil(t, err)
+
+	accrued, err := c.Accrue(a, bs, accountingtest.Date(2020, 1, 1), accountingtest.Date(2020, 1, 21), balance.Week)
+	assert.Nil(t, err)
+	assert.Equal(t, balance.Balances{
+		{Date: accountingtest.Date(2020, 1, 8), Amount: 7 * 100, Currency: gbp},
+		{Date: accountingtest.Date(2020, 1, 15), Amount: 3*100 + 4*200, Currency: gbp},
+		{Date: accountingtest.Date(2020, 1, 21), Amount: 1*200 + 5*400, Currency: gbp},
+	}, accrued)
+}
+
+func TestCalculator_Accrue_CompoundDaily(t *testing.T) {
+	a := *accountingtest.NewAccount(t, "Savings", accountingtest.NewCurrencyCode(t, "GBP"), accountingtest.Date(2020, 1, 1))
+	bs := balance.Balances{{Date: accountingtest.Date(2020, 1, 1), Amount: 100000000}}
+	c, err := interest.New(interest.Schedule{{Date: accountingtest.Date(2020, 1, 1), Annual: 0.05}}, interest.Compounded(interest.Daily))
+	assert.Nil(t, err)
+
+	accrued, err := c.Accrue(a, bs, accountingtest.Date(2020, 1, 1), accountingtest.Date(2021, 1, 1), balance.Year)
+	assert.Nil(t, err)
+	assert.Len(t, accrued, 1)
+	expected := 100000000 * (math.Pow(1+0.05/365, 366) - 1)
+	assert.Equal(t, int(math.Round(expected)), accrued[0].Amount)
+}
+
+func TestCalculator_Accrue_CompoundMonthly(t *testing.T) {
+	a := *accountingtest.NewAccount(t, "Loan", accountingtest.NewCurrencyCode(t, "GBP"), accountingtest.Date(2020, 1, 1), account.OfType(account.Liability))
+	bs := balance.Balances{{Date: accountingtest.Date(2020, 1, 1), Amount: 1200000}}
+	c, err := interest.New(
+		interest.Schedule{{Date: accountingtest.Date(2020, 1, 1), Annual: 0.12}},
+		interest.Compounded(interest.Monthly),
+		interest.Convention(interest.Thirty360),
+	)
+	assert.Nil(t, err)
+
+	accrued, err := c.Accrue(a, bs, accountingtest.Date(2020, 1, 1), accountingtest.Date(2020, 4, 1), balance.Month)
+	assert.Nil(t, err)
+	var amounts []int
+	for _, b := range accrued {
+		amounts = append(amounts, b.Amount)
+	}
+	assert.Equal(t, []int{12000, 12120, 12241}, amounts)
+}
+
+func TestCalculator_Accrue_AccountLifetime(t *testing.T) {
+	a := *accountingtest.NewAccount(t, "Savings", accountingtest.NewCurrencyCode(t, "GBP"), accountingtest.Date(2020, 1, 5), account.CloseTime(accountingtest.Date(2020, 1, 10)))
+	bs := balance.Balances{{Date: accountingtest.Date(2020, 1, 5), Amount: 365000}}
+	c, err := interest.New(interest.Schedule{{Date: accountingtest.Date(2020, 1, 1), Annual: 0.1}})
+	assert.Nil(t, err)
+
+	accrued, err := c.Accrue(a, bs, accountingtest.Date(2020, 1, 1), accountingtest.Date(2020, 2, 1), balance.Month)
+	assert.Nil(t, err)
+	assert.Equal(t, balance.Balances{
+		{Date: accountingtest.Date(2020, 1, 10), Amount: 500, Currency: a.CurrencyCode()},
+	}, accrued)
+
+	accrued, err = c.Accrue(a, bs, accountingtest.Date(2020, 1, 10), accountingtest.Date(2020, 2, 1), balance.Month)
+	assert.Nil(t, err)
+	assert.Empty(t, accrued)
+}
+
+func TestCalculator_Accrue_Errors(t *testing.T) {
+	a := *accountingtest.NewAccount(t, "Savings", accountingtest.NewCurrencyCode(t, "GBP"), accountingtest.Date(2020, 1, 1))
+	c, err := interest.New(interest.Schedule{{Date: accountingtest.Date(2020, 1, 2), Annual: 0.1}})
+	assert.Nil(t, err)
+
+	_, err = c.Accrue(a, nil, accountingtest.Date(2020, 1, 1), accountingtest.Date(2020, 2, 1), balance.Interval(0))
+	assert.Equal(t, errors.New(balance.ErrInvalidInterval), err)
+
+	_, err = c.Accrue(a, balance.Balances{{Date: accountingtest.Date(2019, 1, 1)}}, accountingtest.Date(2020, 1, 1), accountingtest.Date(2020, 2, 1), balance.Month)
+	assert.IsType(t, balance.DateOutOfAccountTimeRange{}, err)
+
+	_, err = c.Accrue(a, nil, accountingtest.Date(2020, 1, 1), accountingtest.Date(2020, 2, 1), balance.Month)
+	assert.EqualError(t, err, "getting Rate at 2020-01-01 00:00:00 +0000 UTC: "+interest.ErrNoRate)
+}
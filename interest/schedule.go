@@ -0,0 +1,34 @@
+package interest
+
+import (
+	"errors"
+	"time"
+
+	"github.com/glynternet/go-accounting/internal/timeseries"
+)
+
+// ErrNoRate is the error message used when a Schedule has no Rate for a given time.
+const ErrNoRate = "no Rate"
+
+// Rate holds an annual interest rate that applies from a given Date.
+// A rate of 5% is given as an Annual value of 0.05.
+type Rate struct {
+	Date   time.Time
+	Annual float64
+}
+
+// Schedule holds the Rates of interest that apply over time.
+type Schedule []Rate
+
+// At returns the Rate that applies at a given time, which is the latest Rate
+// that is at or before the time, using the same semantics as
+// Balances.AtTime: if multiple Rates have the same Date that is the latest,
+// the Rate that was encountered last will be returned.
+// If no Rate applies at the time, an ErrNoRate error is returned.
+func (s Schedule) At(t time.Time) (Rate, error) {
+	i := timeseries.LatestAt(len(s), func(i int) time.Time { return s[i].Date }, t)
+	if i < 0 {
+		return Rate{}, errors.New(ErrNoRate)
+	}
+	return s[i], nil
+}
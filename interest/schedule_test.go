@@ -0,0 +1,28 @@
+package interest_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/glynternet/go-accounting/accountingtest"
+	"github.com/glynternet/go-accounting/interest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSchedule_At(t *testing.T) {
+	s := interest.Schedule{
+		{Date: accountingtest.Date(2020, 6, 1), Annual: 0.02},
+		{Date: accountingtest.Date(2020, 1, 1), Annual: 0.01},
+		{Date: accountingtest.Date(2020, 6, 1), Annual: 0.03},
+	}
+	_, err := s.At(accountingtest.Date(2019, 12, 31))
+	assert.Equal(t, errors.New(interest.ErrNoRate), err)
+
+	r, err := s.At(accountingtest.Date(2020, 5, 31))
+	assert.Nil(t, err)
+	assert.Equal(t, 0.01, r.Annual)
+
+	r, err = s.At(accountingtest.Date(2020, 6, 1))
+	assert.Nil(t, err)
+	assert.Equal(t, 0.03, r.Annual)
+}
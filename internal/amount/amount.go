@@ -0,0 +1,97 @@
+// Package amount parses and formats decimal strings as integer amounts of the
+// minor units of a currency.
+package amount
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Various error messages describing possible errors when parsing an amount.
+const (
+	ErrEmpty         = "empty amount"
+	ErrInvalid       = "invalid amount"
+	ErrTooManyPlaces = "amount has more decimal places than allowed"
+)
+
+// Parse parses a decimal string into an integer amount of minor units, where
+// places is the number of decimal places in a major unit.
+// The integer and fractional parts are separated by decimalSeparator. Spaces,
+// apostrophes and whichever of '.' and ',' is not the decimalSeparator are
+// treated as grouping separators and ignored.
+// A leading '+' or '-', or surrounding parentheses, may be used to denote the
+// sign of the amount.
+// Parse returns an error if the fractional part has more digits than places,
+// rather than rounding.
+func Parse(s string, decimalSeparator rune, places int) (int, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, errors.New(ErrEmpty)
+	}
+	negative := false
+	switch {
+	case strings.HasPrefix(s, "(") && strings.HasSuffix(s, ")"):
+		negative = true
+		s = s[1 : len(s)-1]
+	case strings.HasPrefix(s, "-"):
+		negative = true
+		s = s[1:]
+	case strings.HasPrefix(s, "+"):
+		s = s[1:]
+	}
+	var whole, frac strings.Builder
+	seenSeparator := false
+	for _, r := range s {
+		switch {
+		case r >= '0' && r <= '9':
+			if seenSeparator {
+				frac.WriteRune(r)
+			} else {
+				whole.WriteRune(r)
+			}
+		case r == decimalSeparator && !seenSeparator:
+			seenSeparator = true
+		case r == ' ' || r == '\'' || (r == '.' || r == ',') && r != decimalSeparator && !seenSeparator:
+		default:
+			return 0, errors.Wrapf(errors.New(ErrInvalid), "parsing %q", s)
+		}
+	}
+	if whole.Len() == 0 && frac.Len() == 0 {
+		return 0, errors.Wrapf(errors.New(ErrInvalid), "parsing %q", s)
+	}
+	if frac.Len() > places {
+		return 0, errors.Wrapf(errors.New(ErrTooManyPlaces), "parsing %q", s)
+	}
+	digits := whole.String() + frac.String() + strings.Repeat("0", places-frac.Len())
+	a, err := strconv.Atoi(digits)
+	if err != nil {
+		return 0, errors.Wrapf(err, "parsing %q", s)
+	}
+	if negative {
+		a = -a
+	}
+	return a, nil
+}
+
+// Format formats an integer amount of minor units as a decimal string, where
+// places is the number of decimal places in a major unit.
+// Format never includes grouping separators and uses a leading '-' for
+// negative amounts, so that its output can always be read by Parse.
+func Format(a int, decimalSeparator rune, places int) string {
+	sign := ""
+	if a < 0 {
+		sign = "-"
+		a = -a
+	}
+	digits := strconv.Itoa(a)
+	if places <= 0 {
+		return sign + digits
+	}
+	if len(digits) <= places {
+		digits = strings.Repeat("0", places-len(digits)+1) + digits
+	}
+	split := len(digits) - places
+	return sign + digits[:split] + string(decimalSeparator) + digits[split:]
+}
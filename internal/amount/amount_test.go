@@ -0,0 +1,65 @@
+package amount_test
+
+import (
+	"testing"
+
+	"github.com/glynternet/go-accounting/internal/amount"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	for _, test := range []struct {
+		in        string
+		separator rune
+		places    int
+		expected  int
+		err       bool
+	}{
+		{in: "12.34", separator: '.', places: 2, expected: 1234},
+		{in: "-12.34", separator: '.', places: 2, expected: -1234},
+		{in: "+12.3", separator: '.', places: 2, expected: 1230},
+		{in: "(12.34)", separator: '.', places: 2, expected: -1234},
+		{in: "1,234.56", separator: '.', places: 2, expected: 123456},
+		{in: "1.234,56", separator: ',', places: 2, expected: 123456},
+		{in: "1 234,5", separator: ',', places: 2, expected: 123450},
+		{in: " 12 ", separator: '.', places: 2, expected: 1200},
+		{in: ".5", separator: '.', places: 2, expected: 50},
+		{in: "1500", separator: '.', places: 0, expected: 1500},
+		{in: "", separator: '.', places: 2, err: true},
+		{in: "-", separator: '.', places: 2, err: true},
+		{in: "12.345", separator: '.', places: 2, err: true},
+		{in: "12.3.4", separator: '.', places: 2, err: true},
+		{in: "£12", separator: '.', places: 2, err: true},
+		{in: "1,5", separator: '.', places: 2, expected: 1500},
+	} {
+		a, err := amount.Parse(test.in, test.separator, test.places)
+		if test.err {
+			assert.Error(t, err, test.in)
+			continue
+		}
+		assert.Nil(t, err, test.in)
+		assert.Equal(t, test.expected, a, test.in)
+	}
+}
+
+func TestFormat(t *testing.T) {
+	for _, test := range []struct {
+		in        int
+		separator rune
+		places    int
+		expected  string
+	}{
+		{in: 1234, separator: '.', places: 2, expected: "12.34"},
+		{in: -1234, separator: ',', places: 2, expected: "-12,34"},
+		{in: 5, separator: '.', places: 2, expected: "0.05"},
+		{in: -50, separator: '.', places: 2, expected: "-0.50"},
+		{in: 0, separator: '.', places: 2, expected: "0.00"},
+		{in: 1500, separator: '.', places: 0, expected: "1500"},
+	} {
+		s := amount.Format(test.in, test.separator, test.places)
+		assert.Equal(t, test.expected, s)
+		a, err := amount.Parse(s, test.separator, test.places)
+		assert.Nil(t, err)
+		assert.Equal(t, test.in, a)
+	}
+}
@@ -0,0 +1,25 @@
+// Package timeseries holds the lookups shared by the dated series of the
+// module, such as Balances, exchange Rates and interest Rates, so that they
+// all resolve ties in the same way.
+package timeseries
+
+import "time"
+
+// LatestAt returns the index of the latest of n dated items that is at or
+// before the given time, where date returns the date of the item at index i.
+// If multiple items have the same date that is the latest, the item
+// encountered last is returned. LatestAt returns -1 if no item is at or
+// before the given time.
+func LatestAt(n int, date func(i int) time.Time, at time.Time) int {
+	latest := -1
+	for i := 0; i < n; i++ {
+		d := date(i)
+		if d.After(at) {
+			continue
+		}
+		if latest < 0 || !date(latest).After(d) {
+			latest = i
+		}
+	}
+	return latest
+}
@@ -0,0 +1,28 @@
+package timeseries_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/glynternet/go-accounting/internal/timeseries"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLatestAt(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2020, 1, d, 0, 0, 0, 0, time.UTC) }
+	dates := []time.Time{day(3), day(1), day(3), day(5), day(2)}
+	date := func(i int) time.Time { return dates[i] }
+	for _, test := range []struct {
+		at       time.Time
+		expected int
+	}{
+		{at: day(0), expected: -1},
+		{at: day(1), expected: 1},
+		{at: day(2), expected: 4},
+		{at: day(4), expected: 2},
+		{at: day(9), expected: 3},
+	} {
+		assert.Equal(t, test.expected, timeseries.LatestAt(len(dates), date, test.at), test.at.String())
+	}
+	assert.Equal(t, -1, timeseries.LatestAt(0, date, day(9)))
+}
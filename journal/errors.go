@@ -0,0 +1,78 @@
+package journal
+
+import (
+	"bytes"
+	"fmt"
+	"time"
+
+	"github.com/glynternet/go-money/currency"
+)
+
+// PostingError holds zero or more descriptions of things that are wrong with the Postings of a potential new Entry.
+type PostingError []string
+
+// Error ensures that PostingError adheres to the error interface.
+func (e PostingError) Error() string {
+	var errorString bytes.Buffer
+	errorString.WriteString("PostingError: ")
+	for i, field := range e {
+		errorString.WriteString(field)
+		if i < len(e)-1 {
+			errorString.WriteByte(' ')
+		}
+	}
+	return errorString.String()
+}
+
+// Equal returns true if two PostingErrors contain the same error information strings in exactly the same order.
+func (e PostingError) Equal(other PostingError) bool {
+	if len(e) != len(other) {
+		return false
+	}
+	for i := range e {
+		if e[i] != other[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// Various error strings describing possible errors with the Postings of potential new Entry items.
+const (
+	TooFewPostingsError   = "fewer than two postings"
+	DuplicateAccountError = "multiple postings to the same account"
+)
+
+// ClosedAccountError is returned when an Entry contains a Posting to an Account that is not open at the Date of the Entry.
+type ClosedAccountError struct {
+	AccountName string
+	Date        time.Time
+}
+
+// Error ensures that ClosedAccountError adheres to the error interface.
+func (e ClosedAccountError) Error() string {
+	return fmt.Sprintf("ClosedAccountError: account %q is not open at %s", e.AccountName, e.Date)
+}
+
+// UnbalancedError is returned when the amounts of the Postings of an Entry, all in the same currency.Code, do not sum to zero.
+// Sum holds the value that the Postings sum to.
+type UnbalancedError struct {
+	Currency currency.Code
+	Sum      int
+}
+
+// Error ensures that UnbalancedError adheres to the error interface.
+func (e UnbalancedError) Error() string {
+	return fmt.Sprintf("UnbalancedError: postings in %s sum to %d", e.Currency, e.Sum)
+}
+
+// MixedCurrencyError is returned when an Entry contains Postings in multiple currencies that cannot be balanced per currency.Code.
+// Currencies holds each of the currency.Code values of the Entry, in the order that they were first encountered.
+type MixedCurrencyError struct {
+	Currencies []currency.Code
+}
+
+// Error ensures that MixedCurrencyError adheres to the error interface.
+func (e MixedCurrencyError) Error() string {
+	return fmt.Sprintf("MixedCurrencyError: postings in %v do not balance without a conversion", e.Currencies)
+}
@@ -0,0 +1,39 @@
+package journal_test
+
+import (
+	"testing"
+
+	"github.com/glynternet/go-accounting/journal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPostingError_Error(t *testing.T) {
+	err := journal.PostingError{journal.TooFewPostingsError, journal.DuplicateAccountError}
+	assert.Equal(t, "PostingError: fewer than two postings multiple postings to the same account", err.Error())
+}
+
+func TestPostingError_Equal(t *testing.T) {
+	for _, test := range []struct {
+		errA, errB journal.PostingError
+		equal      bool
+	}{
+		{
+			equal: true,
+		},
+		{
+			errA: journal.PostingError{journal.TooFewPostingsError},
+		},
+		{
+			errA:  journal.PostingError{journal.TooFewPostingsError},
+			errB:  journal.PostingError{journal.TooFewPostingsError},
+			equal: true,
+		},
+		{
+			errA: journal.PostingError{journal.TooFewPostingsError},
+			errB: journal.PostingError{journal.DuplicateAccountError},
+		},
+	} {
+		assert.Equal(t, test.equal, test.errA.Equal(test.errB))
+		assert.Equal(t, test.equal, test.errB.Equal(test.errA))
+	}
+}
@@ -0,0 +1,141 @@
+package journal
+
+import (
+	"time"
+
+	"github.com/glynternet/go-accounting/account"
+	"github.com/glynternet/go-accounting/transaction"
+	"github.com/glynternet/go-money/currency"
+)
+
+// Posting holds the amount that an Entry moves into or out of a single Account.
+type Posting struct {
+	Account account.Account
+	Amount  int
+}
+
+// NewEntry creates a new Entry with a given Date, description and Postings.
+// NewEntry returns the created Entry or an error if the Entry is not valid.
+func NewEntry(date time.Time, description string, ps ...Posting) (*Entry, error) {
+	e := &Entry{
+		Date:        date,
+		Description: description,
+		Postings:    ps,
+	}
+	err := e.Validate()
+	if err != nil {
+		e = nil
+	}
+	return e, err
+}
+
+// Entry holds a set of Postings that occur together at a given Date.
+type Entry struct {
+	Date        time.Time
+	Description string
+	Postings    []Posting
+}
+
+// Validate checks that an Entry adheres to the rules of double-entry bookkeeping.
+// If the Entry has fewer than two Postings or multiple Postings to the same
+// Account, a PostingError is returned.
+// If any Posting is to an Account that is not open at the Date of the Entry,
+// a ClosedAccountError is returned.
+// The amounts of the Postings for each currency.Code must sum to zero. If the
+// Entry is in a single currency and does not balance, an UnbalancedError is
+// returned. If the Entry mixes currencies and any currency does not balance,
+// a MixedCurrencyError is returned, as balancing the Entry would require a
+// conversion between currencies. An Entry that mixes currencies should
+// therefore contain Postings that balance each currency independently.
+func (e Entry) Validate() error {
+	var descriptions []string
+	if len(e.Postings) < 2 {
+		descriptions = append(descriptions, TooFewPostingsError)
+	}
+	if e.hasDuplicateAccounts() {
+		descriptions = append(descriptions, DuplicateAccountError)
+	}
+	if len(descriptions) > 0 {
+		return PostingError(descriptions)
+	}
+	for _, p := range e.Postings {
+		if !p.Account.OpenAt(e.Date) {
+			return ClosedAccountError{
+				AccountName: p.Account.Name(),
+				Date:        e.Date,
+			}
+		}
+	}
+	var codes []currency.Code
+	sums := make(map[currency.Code]int)
+	for _, p := range e.Postings {
+		c := p.Account.CurrencyCode()
+		if _, ok := sums[c]; !ok {
+			codes = append(codes, c)
+		}
+		sums[c] += p.Amount
+	}
+	for _, c := range codes {
+		if sums[c] == 0 {
+			continue
+		}
+		if len(codes) > 1 {
+			return MixedCurrencyError{Currencies: codes}
+		}
+		return UnbalancedError{Currency: c, Sum: sums[c]}
+	}
+	return nil
+}
+
+func (e Entry) hasDuplicateAccounts() bool {
+	for i := range e.Postings {
+		for j := i + 1; j < len(e.Postings); j++ {
+			if e.Postings[i].Account.Equal(e.Postings[j].Account) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Journal holds Entries that have been recorded in the order that they were recorded.
+type Journal struct {
+	entries []Entry
+}
+
+// Record adds an Entry to the Journal.
+// Record returns an error if the Entry is not valid, in which case the Journal is not altered.
+func (j *Journal) Record(e Entry) error {
+	if err := e.Validate(); err != nil {
+		return err
+	}
+	j.entries = append(j.entries, e)
+	return nil
+}
+
+// Entries returns a copy of the Entries recorded in the Journal.
+func (j Journal) Entries() []Entry {
+	es := make([]Entry, len(j.entries))
+	copy(es, j.entries)
+	return es
+}
+
+// Ledger creates a transaction.Ledger for a given Account from each of the
+// Postings of the Journal that are to that Account.
+func (j Journal) Ledger(a account.Account) (*transaction.Ledger, error) {
+	var ts transaction.Transactions
+	for _, e := range j.entries {
+		for _, p := range e.Postings {
+			if !p.Account.Equal(a) {
+				continue
+			}
+			ts = append(ts, transaction.Transaction{
+				Date:        e.Date,
+				Amount:      p.Amount,
+				Description: e.Description,
+				Account:     &a,
+			})
+		}
+	}
+	return transaction.NewLedger(a, ts...)
+}
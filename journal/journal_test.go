@@ -0,0 +1,191 @@
+package journal_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/glynternet/go-accounting/account"
+	"github.com/glynternet/go-accounting/accountingtest"
+	"github.com/glynternet/go-accounting/balance"
+	"github.com/glynternet/go-accounting/journal"
+	"github.com/glynternet/go-money/currency"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewEntry(t *testing.T) {
+	bank := newTestAccount(t, "Bank", "GBP")
+	rent := newTestAccount(t, "Rent", "GBP")
+
+	e, err := journal.NewEntry(newTestDate(2000), "rent",
+		journal.Posting{Account: bank, Amount: -500},
+		journal.Posting{Account: rent, Amount: 500},
+	)
+	assert.Nil(t, err)
+	assert.Equal(t, "rent", e.Description)
+	assert.Len(t, e.Postings, 2)
+
+	e, err = journal.NewEntry(newTestDate(2000), "rent")
+	assert.Equal(t, journal.PostingError{journal.TooFewPostingsError}, err)
+	assert.Nil(t, e)
+}
+
+func TestEntry_Validate(t *testing.T) {
+	gbpA := newTestAccount(t, "A", "GBP")
+	gbpB := newTestAccount(t, "B", "GBP")
+	gbpC := newTestAccount(t, "C", "GBP")
+	eurA := newTestAccount(t, "EUR A", "EUR")
+	eurB := newTestAccount(t, "EUR B", "EUR")
+	closed := newTestAccount(t, "Closed", "GBP", account.CloseTime(newTestDate(1500)))
+
+	for _, test := range []struct {
+		name     string
+		postings []journal.Posting
+		err      error
+	}{
+		{
+			name: "no postings",
+			err:  journal.PostingError{journal.TooFewPostingsError},
+		},
+		{
+			name:     "single posting",
+			postings: []journal.Posting{{Account: gbpA}},
+			err:      journal.PostingError{journal.TooFewPostingsError},
+		},
+		{
+			name: "duplicate accounts",
+			postings: []journal.Posting{
+				{Account: gbpA, Amount: 1},
+				{Account: gbpA, Amount: -1},
+			},
+			err: journal.PostingError{journal.DuplicateAccountError},
+		},
+		{
+			name: "balanced",
+			postings: []journal.Posting{
+				{Account: gbpA, Amount: 100},
+				{Account: gbpB, Amount: -60},
+				{Account: gbpC, Amount: -40},
+			},
+		},
+		{
+			name: "unbalanced",
+			postings: []journal.Posting{
+				{Account: gbpA, Amount: 100},
+				{Account: gbpB, Amount: -60},
+			},
+			err: journal.UnbalancedError{Currency: newTestCurrency(t, "GBP"), Sum: 40},
+		},
+		{
+			name: "closed account",
+			postings: []journal.Posting{
+				{Account: gbpA, Amount: 100},
+				{Account: closed, Amount: -100},
+			},
+			err: journal.ClosedAccountError{AccountName: "Closed", Date: newTestDate(2000)},
+		},
+		{
+			name: "mixed currencies balanced per currency",
+			postings: []journal.Posting{
+				{Account: gbpA, Amount: 100},
+				{Account: gbpB, Amount: -100},
+				{Account: eurA, Amount: 120},
+				{Account: eurB, Amount: -120},
+			},
+		},
+		{
+			name: "mixed currencies without conversion",
+			postings: []journal.Posting{
+				{Account: gbpA, Amount: -100},
+				{Account: eurA, Amount: 120},
+			},
+			err: journal.MixedCurrencyError{
+				Currencies: []currency.Code{newTestCurrency(t, "GBP"), newTestCurrency(t, "EUR")},
+			},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			e := journal.Entry{Date: newTestDate(2000), Postings: test.postings}
+			assert.Equal(t, test.err, e.Validate())
+		})
+	}
+}
+
+func TestJournal_Record(t *testing.T) {
+	a := newTestAccount(t, "A", "GBP")
+	b := newTestAccount(t, "B", "GBP")
+	var j journal.Journal
+
+	valid := journal.Entry{
+		Date: newTestDate(2000),
+		Postings: []journal.Posting{
+			{Account: a, Amount: 10},
+			{Account: b, Amount: -10},
+		},
+	}
+	assert.Nil(t, j.Record(valid))
+	assert.Equal(t, []journal.Entry{valid}, j.Entries())
+
+	invalid := journal.Entry{
+		Date: newTestDate(2000),
+		Postings: []journal.Posting{
+			{Account: a, Amount: 10},
+			{Account: b, Amount: -9},
+		},
+	}
+	assert.IsType(t, journal.UnbalancedError{}, j.Record(invalid))
+	assert.Equal(t, []journal.Entry{valid}, j.Entries())
+}
+
+func TestJournal_Ledger(t *testing.T) {
+	bank := newTestAccount(t, "Bank", "GBP")
+	salary := newTestAccount(t, "Salary", "GBP")
+	food := newTestAccount(t, "Food", "GBP")
+	var j journal.Journal
+	for _, e := range []journal.Entry{
+		{
+			Date:        newTestDate(2001),
+			Description: "groceries",
+			Postings: []journal.Posting{
+				{Account: bank, Amount: -30},
+				{Account: food, Amount: 30},
+			},
+		},
+		{
+			Date:        newTestDate(2000),
+			Description: "salary",
+			Postings: []journal.Posting{
+				{Account: bank, Amount: 1000},
+				{Account: salary, Amount: -1000},
+			},
+		},
+	} {
+		assert.Nil(t, j.Record(e))
+	}
+
+	l, err := j.Ledger(bank)
+	assert.Nil(t, err)
+	assert.Len(t, l.Transactions(), 2)
+	bs, err := l.Balances()
+	assert.Nil(t, err)
+	assert.Equal(t, balance.Balances{
+		{Date: newTestDate(2000), Amount: 1000, Currency: newTestCurrency(t, "GBP")},
+		{Date: newTestDate(2001), Amount: 970, Currency: newTestCurrency(t, "GBP")},
+	}, bs)
+
+	l, err = j.Ledger(food)
+	assert.Nil(t, err)
+	assert.Len(t, l.Transactions(), 1)
+	assert.Equal(t, "groceries", l.Transactions()[0].Description)
+}
+
+func newTestAccount(t *testing.T, name, code string, os ...account.Option) account.Account {
+	return *accountingtest.NewAccount(t, name, newTestCurrency(t, code), newTestDate(1000), os...)
+}
+
+func newTestCurrency(t *testing.T, code string) currency.Code {
+	return accountingtest.NewCurrencyCode(t, code)
+}
+
+func newTestDate(year int) time.Time {
+	return time.Date(year, 1, 1, 1, 1, 1, 1, time.UTC)
+}
@@ -0,0 +1,51 @@
+package ofx
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// parseDateTime parses an OFX date and time value of the form
+// YYYYMMDD[HHMMSS[.XXX]][[gmt offset[:tz name]]], such as
+// 20180102 or 20180102153000.000[-5:EST].
+// Values without an offset are interpreted as UTC.
+func parseDateTime(s string) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	location := time.UTC
+	if i := strings.IndexByte(s, '['); i >= 0 {
+		if !strings.HasSuffix(s, "]") {
+			return time.Time{}, errors.Errorf("invalid time zone in %q", s)
+		}
+		zone := s[i+1 : len(s)-1]
+		s = s[:i]
+		name := ""
+		if j := strings.IndexByte(zone, ':'); j >= 0 {
+			zone, name = zone[:j], zone[j+1:]
+		}
+		hours, err := strconv.ParseFloat(zone, 64)
+		if err != nil {
+			return time.Time{}, errors.Wrapf(err, "parsing offset of %q", s)
+		}
+		if name == "" {
+			name = "GMT" + zone
+		}
+		location = time.FixedZone(name, int(hours*60*60))
+	}
+	var layout string
+	switch {
+	case len(s) == 8:
+		layout = "20060102"
+	case len(s) == 12:
+		layout = "200601021504"
+	case len(s) == 14:
+		layout = "20060102150405"
+	case len(s) > 15 && s[14] == '.':
+		layout = "20060102150405." + strings.Repeat("0", len(s)-15)
+	default:
+		return time.Time{}, errors.Errorf("invalid date time %q", s)
+	}
+	return time.ParseInLocation(layout, s, location)
+}
@@ -0,0 +1,51 @@
+package ofx
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_parseDateTime(t *testing.T) {
+	for _, test := range []struct {
+		in       string
+		expected time.Time
+		err      bool
+	}{
+		{in: "20180102", expected: time.Date(2018, 1, 2, 0, 0, 0, 0, time.UTC)},
+		{in: "201801021504", expected: time.Date(2018, 1, 2, 15, 4, 0, 0, time.UTC)},
+		{in: "20180102150405", expected: time.Date(2018, 1, 2, 15, 4, 5, 0, time.UTC)},
+		{in: "20180102150405.123", expected: time.Date(2018, 1, 2, 15, 4, 5, 123000000, time.UTC)},
+		{in: "20180102150405.000[-5:EST]", expected: time.Date(2018, 1, 2, 20, 4, 5, 0, time.UTC)},
+		{in: "20180102150405[5.5]", expected: time.Date(2018, 1, 2, 9, 34, 5, 0, time.UTC)},
+		{in: "", err: true},
+		{in: "2018-01-02", err: true},
+		{in: "20180102[-5:EST", err: true},
+		{in: "20180102[x:EST]", err: true},
+	} {
+		actual, err := parseDateTime(test.in)
+		if test.err {
+			assert.Error(t, err, test.in)
+			continue
+		}
+		assert.Nil(t, err, test.in)
+		assert.True(t, test.expected.Equal(actual), "%s\nExpected: %s\nActual  : %s", test.in, test.expected, actual)
+	}
+}
+
+func Test_parseTree(t *testing.T) {
+	for _, doc := range []string{
+		"<OFX><A><B>1<C>2</A><D>3</D></OFX>",
+		"<OFX>\n  <A>\n    <B>1</B>\n    <C>2</C>\n  </A>\n  <D>3</D>\n</OFX>",
+	} {
+		root, err := parseTree(doc)
+		if !assert.Nil(t, err, doc) {
+			continue
+		}
+		assert.Equal(t, "1", root.path("A", "B"), doc)
+		assert.Equal(t, "2", root.path("A", "C"), doc)
+		assert.Equal(t, "3", root.path("D"), doc)
+		assert.Equal(t, "", root.path("A", "D"), doc)
+	}
+}
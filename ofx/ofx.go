@@ -0,0 +1,248 @@
+// Package ofx parses OFX and QFX statement downloads into Accounts and Balances.
+//
+// Both OFX 1.x documents, which are SGML with a plain text header, and OFX 2.x
+// documents, which are XML, are supported.
+package ofx
+
+import (
+	"io"
+	"io/ioutil"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/glynternet/go-accounting/account"
+	"github.com/glynternet/go-accounting/balance"
+	"github.com/glynternet/go-accounting/internal/amount"
+	"github.com/glynternet/go-accounting/transaction"
+	"github.com/glynternet/go-money/currency"
+	"github.com/pkg/errors"
+)
+
+// Various error messages describing possible errors when parsing an OFX document.
+const (
+	ErrNoOFXElement = "no OFX element found"
+	ErrNoStatements = "no statements found"
+)
+
+// decimalPlaces is the number of decimal places that amounts are read with.
+const decimalPlaces = 2
+
+// Statement holds an Account described by an OFX document along with its
+// Balances and Transactions.
+type Statement struct {
+	Account      account.Account
+	Balances     balance.Balances
+	Transactions transaction.Transactions
+}
+
+// Parse parses an OFX document, returning a Statement for each distinct
+// account found in its bank and credit card statement responses.
+//
+// The Account of each Statement is named after the ACCTID of the account,
+// uses the currency of its CURDEF, and is opened at the earliest DTSTART, or
+// earliest transaction if that is earlier, found across all of its
+// statements. Bank accounts are Assets and credit card accounts are Liabilities.
+//
+// The Balances of each Statement are derived from the LEDGERBAL of each
+// statement, or the AVAILBAL where no LEDGERBAL is present. A Balance is given
+// at the DTASOF of the ledger balance and, working backwards through the
+// transactions posted up to that time, after each transaction.
+func Parse(r io.Reader) ([]Statement, error) {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, errors.Wrap(err, "reading document")
+	}
+	body := string(data)
+	start := strings.Index(strings.ToUpper(body), "<OFX>")
+	if start < 0 {
+		return nil, errors.New(ErrNoOFXElement)
+	}
+	root, err := parseTree(body[start:])
+	if err != nil {
+		return nil, errors.Wrap(err, "parsing document")
+	}
+
+	var order []string
+	statements := make(map[string]*rawStatement)
+	for _, kind := range []struct {
+		response, from string
+		account.Type
+	}{
+		{response: "STMTRS", from: "BANKACCTFROM", Type: account.Asset},
+		{response: "CCSTMTRS", from: "CCACCTFROM", Type: account.Liability},
+	} {
+		for _, rs := range root.all(kind.response) {
+			s, err := parseStatement(rs, kind.from)
+			if err != nil {
+				return nil, err
+			}
+			s.accountType = kind.Type
+			existing, ok := statements[s.accountID]
+			if !ok {
+				order = append(order, s.accountID)
+				statements[s.accountID] = s
+				continue
+			}
+			if err := existing.merge(*s); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if len(order) == 0 {
+		return nil, errors.New(ErrNoStatements)
+	}
+	var ss []Statement
+	for _, id := range order {
+		s, err := statements[id].statement()
+		if err != nil {
+			return nil, errors.Wrapf(err, "creating statement for account %s", id)
+		}
+		ss = append(ss, *s)
+	}
+	return ss, nil
+}
+
+// rawStatement holds the data read from one or more statement responses for a single account.
+type rawStatement struct {
+	accountID    string
+	accountType  account.Type
+	currency     currency.Code
+	start        time.Time
+	transactions transaction.Transactions
+	balances     balance.Balances
+}
+
+func parseStatement(rs *element, from string) (*rawStatement, error) {
+	id := rs.path(from, "ACCTID")
+	if id == "" {
+		return nil, errors.Errorf("%s has no ACCTID", from)
+	}
+	c, err := currency.NewCode(strings.ToUpper(rs.path("CURDEF")))
+	if err != nil {
+		return nil, errors.Wrapf(err, "parsing CURDEF of account %s", id)
+	}
+	s := &rawStatement{accountID: id, currency: *c}
+	if list := rs.child("BANKTRANLIST"); list != nil {
+		if v := list.path("DTSTART"); v != "" {
+			if s.start, err = parseDateTime(v); err != nil {
+				return nil, errors.Wrapf(err, "parsing DTSTART of account %s", id)
+			}
+		}
+		for _, e := range list.all("STMTTRN") {
+			t, err := parseTransaction(e)
+			if err != nil {
+				return nil, errors.Wrapf(err, "parsing transaction of account %s", id)
+			}
+			s.transactions = append(s.transactions, *t)
+		}
+	}
+	bal := rs.child("LEDGERBAL")
+	if bal == nil {
+		bal = rs.child("AVAILBAL")
+	}
+	if bal != nil {
+		b, err := parseBalance(bal)
+		if err != nil {
+			return nil, errors.Wrapf(err, "parsing %s of account %s", bal.name, id)
+		}
+		s.balances = s.derive(*b)
+	}
+	return s, nil
+}
+
+// derive returns the Balances before the given Balance, working backwards
+// through the transactions posted up to its date, followed by the Balance itself.
+func (s rawStatement) derive(b balance.Balance) balance.Balances {
+	var posted transaction.Transactions
+	for _, t := range s.transactions.Sorted() {
+		if !t.Date.After(b.Date) {
+			posted = append(posted, t)
+		}
+	}
+	bs := make(balance.Balances, len(posted)+1)
+	bs[len(posted)] = b
+	running := b.Amount
+	for i := len(posted) - 1; i >= 0; i-- {
+		bs[i] = balance.Balance{Date: posted[i].Date, Amount: running}
+		running -= posted[i].Amount
+	}
+	return bs
+}
+
+func (s *rawStatement) merge(o rawStatement) error {
+	if s.currency != o.currency {
+		return errors.Errorf("statements for account %s have different currencies", s.accountID)
+	}
+	if s.start.IsZero() || (!o.start.IsZero() && o.start.Before(s.start)) {
+		s.start = o.start
+	}
+	s.transactions = append(s.transactions, o.transactions...)
+	s.balances = append(s.balances, o.balances...)
+	return nil
+}
+
+func (s rawStatement) statement() (*Statement, error) {
+	opened := s.start
+	for _, t := range s.transactions {
+		if opened.IsZero() || t.Date.Before(opened) {
+			opened = t.Date
+		}
+	}
+	for _, b := range s.balances {
+		if opened.IsZero() || b.Date.Before(opened) {
+			opened = b.Date
+		}
+	}
+	a, err := account.New(s.accountID, s.currency, opened, account.OfType(s.accountType))
+	if err != nil {
+		return nil, err
+	}
+	st := &Statement{Account: *a}
+	for _, t := range s.transactions.Sorted() {
+		t.Account = a
+		st.Transactions = append(st.Transactions, t)
+	}
+	for _, b := range s.balances {
+		b.Currency = s.currency
+		if err := a.ValidateBalance(b); err != nil {
+			return nil, err
+		}
+		st.Balances = append(st.Balances, b)
+	}
+	sort.SliceStable(st.Balances, func(i, j int) bool {
+		return st.Balances[i].Date.Before(st.Balances[j].Date)
+	})
+	return st, nil
+}
+
+func parseTransaction(e *element) (*transaction.Transaction, error) {
+	date, err := parseDateTime(e.path("DTPOSTED"))
+	if err != nil {
+		return nil, errors.Wrap(err, "parsing DTPOSTED")
+	}
+	a, err := amount.Parse(e.path("TRNAMT"), '.', decimalPlaces)
+	if err != nil {
+		return nil, errors.Wrap(err, "parsing TRNAMT")
+	}
+	description := e.path("NAME")
+	if memo := e.path("MEMO"); memo != "" {
+		if description != "" {
+			description += " "
+		}
+		description += memo
+	}
+	return &transaction.Transaction{Date: date, Amount: a, Description: description}, nil
+}
+
+func parseBalance(e *element) (*balance.Balance, error) {
+	date, err := parseDateTime(e.path("DTASOF"))
+	if err != nil {
+		return nil, errors.Wrap(err, "parsing DTASOF")
+	}
+	a, err := amount.Parse(e.path("BALAMT"), '.', decimalPlaces)
+	if err != nil {
+		return nil, errors.Wrap(err, "parsing BALAMT")
+	}
+	return &balance.Balance{Date: date, Amount: a}, nil
+}
@@ -0,0 +1,146 @@
+package ofx_test
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/glynternet/go-accounting/account"
+	"github.com/glynternet/go-accounting/accountingtest"
+	"github.com/glynternet/go-accounting/balance"
+	"github.com/glynternet/go-accounting/ofx"
+	"github.com/glynternet/go-money/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse_BankV1(t *testing.T) {
+	ss := parseFixture(t, "testdata/bank_v1.ofx")
+	if !assert.Len(t, ss, 1) {
+		return
+	}
+	s := ss[0]
+	gbp := accountingtest.NewCurrencyCode(t, "GBP")
+	assert.Equal(t, "12345678", s.Account.Name())
+	assert.Equal(t, gbp, s.Account.CurrencyCode())
+	assert.Equal(t, account.Asset, s.Account.Type())
+	assert.True(t, s.Account.Opened().Equal(utc(2018, 2, 1)))
+	assert.False(t, s.Account.Closed().Valid)
+
+	assertBalancesEqual(t, balance.Balances{
+		{Date: utc(2018, 2, 1), Amount: 260000, Currency: gbp},
+		{Date: utc(2018, 2, 5), Amount: 140000, Currency: gbp},
+		{Date: utc(2018, 2, 10), Amount: 135433, Currency: gbp},
+		{Date: utc(2018, 2, 28), Amount: 135433, Currency: gbp},
+	}, s.Balances)
+
+	if assert.Len(t, s.Transactions, 3) {
+		assert.Equal(t, "SALARY", s.Transactions[0].Description)
+		assert.Equal(t, -120000, s.Transactions[1].Amount)
+		assert.Equal(t, "SUPERMARKET Groceries & household", s.Transactions[2].Description)
+		assert.True(t, s.Transactions[2].Account.Equal(s.Account))
+	}
+}
+
+func TestParse_CreditCardV2(t *testing.T) {
+	ss := parseFixture(t, "testdata/creditcard_v2.qfx")
+	if !assert.Len(t, ss, 1) {
+		return
+	}
+	s := ss[0]
+	usd := accountingtest.NewCurrencyCode(t, "USD")
+	est := time.FixedZone("EST", -5*60*60)
+	assert.Equal(t, "4111111111111111", s.Account.Name())
+	assert.Equal(t, usd, s.Account.CurrencyCode())
+	assert.Equal(t, account.Liability, s.Account.Type())
+	assert.True(t, s.Account.Opened().Equal(time.Date(2018, 3, 1, 0, 0, 0, 0, est)))
+
+	assertBalancesEqual(t, balance.Balances{
+		{Date: time.Date(2018, 3, 2, 12, 0, 0, 0, est), Amount: -25050, Currency: usd},
+		{Date: time.Date(2018, 3, 15, 12, 0, 0, 0, est), Amount: -15050, Currency: usd},
+		{Date: time.Date(2018, 3, 31, 0, 0, 0, 0, est), Amount: -15050, Currency: usd},
+	}, s.Balances)
+	assert.Len(t, s.Transactions, 2)
+}
+
+func TestParse_MultipleStatements(t *testing.T) {
+	const doc = `<OFX><BANKMSGSRSV1>
+<STMTTRNRS><STMTRS><CURDEF>EUR<BANKACCTFROM><ACCTID>A</BANKACCTFROM>
+<BANKTRANLIST><DTSTART>20180201</BANKTRANLIST>
+<AVAILBAL><BALAMT>10<DTASOF>20180228</AVAILBAL></STMTRS></STMTTRNRS>
+<STMTTRNRS><STMTRS><CURDEF>EUR<BANKACCTFROM><ACCTID>A</BANKACCTFROM>
+<BANKTRANLIST><DTSTART>20180101</BANKTRANLIST>
+<LEDGERBAL><BALAMT>5<DTASOF>20180131</LEDGERBAL></STMTRS></STMTTRNRS>
+<STMTTRNRS><STMTRS><CURDEF>EUR<BANKACCTFROM><ACCTID>B</BANKACCTFROM>
+<LEDGERBAL><BALAMT>1<DTASOF>20180131</LEDGERBAL></STMTRS></STMTTRNRS>
+</BANKMSGSRSV1></OFX>`
+	ss, err := ofx.Parse(strings.NewReader(doc))
+	common.FatalIfError(t, err, "parsing document")
+	if !assert.Len(t, ss, 2) {
+		return
+	}
+	eur := accountingtest.NewCurrencyCode(t, "EUR")
+	assert.Equal(t, "A", ss[0].Account.Name())
+	assert.True(t, ss[0].Account.Opened().Equal(utc(2018, 1, 1)))
+	assertBalancesEqual(t, balance.Balances{
+		{Date: utc(2018, 1, 31), Amount: 500, Currency: eur},
+		{Date: utc(2018, 2, 28), Amount: 1000, Currency: eur},
+	}, ss[0].Balances)
+	assert.Equal(t, "B", ss[1].Account.Name())
+	assert.True(t, ss[1].Account.Opened().Equal(utc(2018, 1, 31)))
+}
+
+func TestParse_Invalid(t *testing.T) {
+	for _, test := range []struct {
+		name string
+		doc  string
+	}{
+		{name: "empty"},
+		{name: "no OFX element", doc: "<HTML></HTML>"},
+		{name: "no statements", doc: "<OFX><SIGNONMSGSRSV1></SIGNONMSGSRSV1></OFX>"},
+		{name: "unterminated tag", doc: "<OFX><STMTRS"},
+		{name: "unexpected end tag", doc: "<OFX></STMTRS></OFX>"},
+		{name: "missing account id", doc: "<OFX><STMTRS><CURDEF>GBP</STMTRS></OFX>"},
+		{name: "invalid currency", doc: "<OFX><STMTRS><CURDEF>POUNDS<BANKACCTFROM><ACCTID>A</BANKACCTFROM></STMTRS></OFX>"},
+		{
+			name: "invalid amount",
+			doc: "<OFX><STMTRS><CURDEF>GBP<BANKACCTFROM><ACCTID>A</BANKACCTFROM>" +
+				"<BANKTRANLIST><STMTTRN><DTPOSTED>20180101<TRNAMT>ten</STMTTRN></BANKTRANLIST></STMTRS></OFX>",
+		},
+		{
+			name: "invalid date",
+			doc: "<OFX><STMTRS><CURDEF>GBP<BANKACCTFROM><ACCTID>A</BANKACCTFROM>" +
+				"<LEDGERBAL><BALAMT>1<DTASOF>2018-01-01</LEDGERBAL></STMTRS></OFX>",
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			ss, err := ofx.Parse(strings.NewReader(test.doc))
+			assert.Error(t, err)
+			assert.Nil(t, ss)
+		})
+	}
+}
+
+func parseFixture(t *testing.T, path string) []ofx.Statement {
+	f, err := os.Open(path)
+	common.FatalIfError(t, err, "opening fixture")
+	defer func() {
+		common.ErrorIfError(t, f.Close(), "closing fixture")
+	}()
+	ss, err := ofx.Parse(f)
+	common.FatalIfError(t, err, "parsing fixture")
+	return ss
+}
+
+func assertBalancesEqual(t *testing.T, expected, actual balance.Balances) {
+	if !assert.Len(t, actual, len(expected)) {
+		return
+	}
+	for i := range expected {
+		assert.True(t, expected[i].Equal(actual[i]), "[%d]\nExpected: %+v\nActual  : %+v", i, expected[i], actual[i])
+	}
+}
+
+func utc(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
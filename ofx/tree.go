@@ -0,0 +1,112 @@
+package ofx
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// element is a node of the tree of an OFX document.
+// Aggregate elements hold children while elements holding data hold a value.
+type element struct {
+	name     string
+	value    string
+	children []*element
+}
+
+// child returns the first direct child of the element with the given name.
+func (e *element) child(name string) *element {
+	for _, c := range e.children {
+		if c.name == name {
+			return c
+		}
+	}
+	return nil
+}
+
+// all returns every element in the tree below e with the given name, in document order.
+func (e *element) all(name string) []*element {
+	var found []*element
+	for _, c := range e.children {
+		if c.name == name {
+			found = append(found, c)
+		}
+		found = append(found, c.all(name)...)
+	}
+	return found
+}
+
+// path returns the value of the element found by following the given names
+// from e, or an empty string if no such element exists.
+func (e *element) path(names ...string) string {
+	cur := e
+	for _, n := range names {
+		if cur = cur.child(n); cur == nil {
+			return ""
+		}
+	}
+	return cur.value
+}
+
+var unescaper = strings.NewReplacer("&lt;", "<", "&gt;", ">", "&quot;", `"`, "&apos;", "'", "&nbsp;", " ", "&amp;", "&")
+
+// parseTree parses the body of an OFX document into a tree of elements.
+// The body must start with the OFX element; any headers must already have
+// been removed.
+// parseTree handles both OFX 1.x SGML, in which elements holding data have no
+// end tag, and OFX 2.x XML, in which every element is closed.
+func parseTree(body string) (*element, error) {
+	root := &element{}
+	stack := []*element{root}
+	leafOpen := false
+	for len(body) > 0 {
+		start := strings.IndexByte(body, '<')
+		if start < 0 {
+			if strings.TrimSpace(body) != "" {
+				return nil, errors.New("unexpected data after final tag")
+			}
+			break
+		}
+		if text := strings.TrimSpace(body[:start]); text != "" {
+			if leafOpen || len(stack) == 1 {
+				return nil, errors.Errorf("unexpected data %q", text)
+			}
+			stack[len(stack)-1].value = unescaper.Replace(text)
+			leafOpen = true
+		}
+		end := strings.IndexByte(body[start:], '>')
+		if end < 0 {
+			return nil, errors.New("unterminated tag")
+		}
+		tag := strings.TrimSpace(body[start+1 : start+end])
+		body = body[start+end+1:]
+		switch {
+		case tag == "" || tag == "/":
+			return nil, errors.New("empty tag")
+		case strings.HasPrefix(tag, "?"), strings.HasPrefix(tag, "!"):
+			continue
+		case strings.HasPrefix(tag, "/"):
+			name := strings.ToUpper(strings.TrimSpace(tag[1:]))
+			i := len(stack) - 1
+			for ; i > 0 && stack[i].name != name; i-- {
+			}
+			if i == 0 {
+				return nil, errors.Errorf("unexpected end tag %q", name)
+			}
+			stack = stack[:i]
+		default:
+			if leafOpen {
+				stack = stack[:len(stack)-1]
+			}
+			e := &element{name: strings.ToUpper(strings.Fields(tag)[0])}
+			parent := stack[len(stack)-1]
+			parent.children = append(parent.children, e)
+			stack = append(stack, e)
+		}
+		leafOpen = false
+	}
+	if len(root.children) != 1 || root.children[0].name != "OFX" {
+		return nil, errors.New("document does not have a single OFX root element")
+	}
+	return root.children[0], nil
+}
@@ -0,0 +1,34 @@
+package qif
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Option is a function that takes a pointer to a Codec returning an error.
+// The idea of Option is to alter a Codec object
+type Option func(*Codec) error
+
+// DecimalPlaces is an Option that sets the number of decimal places in a major
+// unit of the currency of the Account.
+func DecimalPlaces(n int) Option {
+	return func(c *Codec) error {
+		if n < 0 {
+			return errors.Errorf("invalid decimal places %d", n)
+		}
+		c.decimalPlaces = n
+		return nil
+	}
+}
+
+// Location is an Option that sets the Location in which dates are read and written.
+func Location(l *time.Location) Option {
+	return func(c *Codec) error {
+		if l == nil {
+			return errors.New("nil Location")
+		}
+		c.location = l
+		return nil
+	}
+}
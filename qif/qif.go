@@ -0,0 +1,191 @@
+// Package qif reads and writes Balances in the Quicken Interchange Format.
+//
+// QIF describes an account as a list of transactions. When reading, the
+// running total of the transactions is used as the Balances of the Account.
+// When writing, each Balance is written as a transaction of the difference
+// from the previous Balance, so that reading the written QIF will produce the
+// original Balances, as long as the date layout of the Codec can hold the
+// Date of every Balance exactly.
+package qif
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/glynternet/go-accounting/account"
+	"github.com/glynternet/go-accounting/balance"
+	"github.com/glynternet/go-accounting/internal/amount"
+	"github.com/pkg/errors"
+)
+
+// TypeBank is the header of a QIF file that holds bank account records.
+const TypeBank = "!Type:Bank"
+
+// Various error messages describing possible errors when reading QIF.
+const (
+	ErrEmptyLayout       = "empty date layout"
+	ErrUnsupportedType   = "unsupported QIF type"
+	ErrMissingDate       = "record has no date"
+	ErrMissingAmount     = "record has no amount"
+	ErrUnterminatedEntry = "record is not terminated"
+	ErrInexactDate       = "Balance date cannot be written exactly with the date layout"
+)
+
+// New creates a new Codec that reads and writes dates using the given layout,
+// as used by time.Parse and time.Format.
+// QIF dates are often written with an apostrophe before the year and with
+// spaces in place of leading zeros, such as 1/ 2'18. Before parsing, the
+// apostrophe is replaced with a '/' and spaces are removed from both the date
+// and the layout, so that date could be read with the layout "1/2/06" or
+// "1/2'06". The layout is used unaltered to write dates.
+// By default, amounts have two decimal places and dates are interpreted as UTC.
+func New(dateLayout string, os ...Option) (*Codec, error) {
+	if strings.TrimSpace(dateLayout) == "" {
+		return nil, errors.New(ErrEmptyLayout)
+	}
+	c := &Codec{
+		dateLayout:    dateLayout,
+		decimalPlaces: 2,
+		location:      time.UTC,
+	}
+	for _, o := range os {
+		if o == nil {
+			continue
+		}
+		if err := o(c); err != nil {
+			return nil, err
+		}
+	}
+	return c, nil
+}
+
+// Codec holds the formatting used to read and write QIF.
+type Codec struct {
+	dateLayout    string
+	decimalPlaces int
+	location      *time.Location
+}
+
+// Read reads a !Type:Bank QIF file, returning the running total after each
+// record as a Balance in the currency of the Account.
+// Only the D (date) and T (amount) fields of each record are used; all other
+// fields are ignored.
+// Each Balance is validated through Account.ValidateBalance.
+func (c Codec) Read(r io.Reader, a account.Account) (balance.Balances, error) {
+	s := bufio.NewScanner(r)
+	line := 0
+	header := ""
+	for header == "" && s.Scan() {
+		line++
+		header = strings.TrimSpace(s.Text())
+	}
+	if err := s.Err(); err != nil {
+		return nil, errors.Wrap(err, "reading QIF")
+	}
+	if !strings.EqualFold(header, TypeBank) {
+		return nil, errors.Wrapf(errors.New(ErrUnsupportedType), "line %d: %q", line, header)
+	}
+	var (
+		bs          balance.Balances
+		running     int
+		date        *time.Time
+		amt         *int
+		inRecord    bool
+		recordStart int
+	)
+	for s.Scan() {
+		line++
+		text := strings.TrimRight(s.Text(), "\r")
+		if strings.TrimSpace(text) == "" {
+			continue
+		}
+		if !inRecord {
+			inRecord = true
+			recordStart = line
+		}
+		code, value := text[0], strings.TrimSpace(text[1:])
+		switch code {
+		case 'D':
+			d, err := c.parseDate(value)
+			if err != nil {
+				return nil, errors.Wrapf(err, "line %d", line)
+			}
+			date = &d
+		case 'T':
+			v, err := amount.Parse(value, '.', c.decimalPlaces)
+			if err != nil {
+				return nil, errors.Wrapf(err, "line %d", line)
+			}
+			amt = &v
+		case '!':
+			return nil, errors.Wrapf(errors.New(ErrUnsupportedType), "line %d: %q", line, text)
+		case '^':
+			if date == nil {
+				return nil, errors.Wrapf(errors.New(ErrMissingDate), "line %d", recordStart)
+			}
+			if amt == nil {
+				return nil, errors.Wrapf(errors.New(ErrMissingAmount), "line %d", recordStart)
+			}
+			running += *amt
+			b := balance.Balance{Date: *date, Amount: running, Currency: a.CurrencyCode()}
+			if err := a.ValidateBalance(b); err != nil {
+				return nil, errors.Wrapf(err, "line %d", recordStart)
+			}
+			bs = append(bs, b)
+			date, amt, inRecord = nil, nil, false
+		}
+	}
+	if err := s.Err(); err != nil {
+		return nil, errors.Wrap(err, "reading QIF")
+	}
+	if inRecord {
+		return nil, errors.Wrapf(errors.New(ErrUnterminatedEntry), "line %d", recordStart)
+	}
+	return bs, nil
+}
+
+// Write writes the Balances of an Account as a !Type:Bank QIF file, with a
+// record for each Balance holding the difference from the previous Balance.
+// The first record holds the whole Amount of the first Balance.
+// Each Balance is validated through Account.ValidateBalance before anything is written.
+// Dates are written with the date layout of the Codec in its location, so any
+// part of a Date that the layout does not hold would be lost. For example,
+// with a layout of only a day, month and year, two Balances at different
+// times of the same day could not be told apart when read back. Write returns
+// an ErrInexactDate error, without writing anything, for any Balance with a
+// Date that would not be read back exactly.
+func (c Codec) Write(w io.Writer, a account.Account, bs balance.Balances) error {
+	for i, b := range bs {
+		if err := a.ValidateBalance(b); err != nil {
+			return errors.Wrapf(err, "validating Balance at index %d", i)
+		}
+		if d, err := c.parseDate(c.formatDate(b.Date)); err != nil || !d.Equal(b.Date) {
+			return errors.Wrapf(errors.New(ErrInexactDate), "Balance at index %d: %s", i, b.Date)
+		}
+	}
+	bw := bufio.NewWriter(w)
+	fmt.Fprintln(bw, TypeBank)
+	previous := 0
+	for _, b := range bs {
+		fmt.Fprintf(bw, "D%s\n", c.formatDate(b.Date))
+		fmt.Fprintf(bw, "T%s\n", amount.Format(b.Amount-previous, '.', c.decimalPlaces))
+		fmt.Fprintln(bw, "^")
+		previous = b.Amount
+	}
+	return errors.Wrap(bw.Flush(), "writing QIF")
+}
+
+func (c Codec) formatDate(t time.Time) string {
+	return t.In(c.location).Format(c.dateLayout)
+}
+
+func (c Codec) parseDate(value string) (time.Time, error) {
+	return time.ParseInLocation(normaliseDate(c.dateLayout), normaliseDate(value), c.location)
+}
+
+func normaliseDate(s string) string {
+	return strings.Replace(strings.Replace(s, "'", "/", -1), " ", "", -1)
+}
@@ -0,0 +1,204 @@
+package qif_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/glynternet/go-accounting/account"
+	"github.com/glynternet/go-accounting/accountingtest"
+	"github.com/glynternet/go-accounting/balance"
+	"github.com/glynternet/go-accounting/qif"
+	"github.com/glynternet/go-money/common"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNew(t *testing.T) {
+	_, err := qif.New("01/02/2006")
+	assert.Nil(t, err)
+	for _, test := range []struct {
+		name    string
+		layout  string
+		options []qif.Option
+	}{
+		{name: "empty layout"},
+		{name: "invalid places", layout: "2006", options: []qif.Option{qif.DecimalPlaces(-1)}},
+		{name: "nil location", layout: "2006", options: []qif.Option{qif.Location(nil)}},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			c, err := qif.New(test.layout, test.options...)
+			assert.Error(t, err)
+			assert.Nil(t, c)
+		})
+	}
+}
+
+func TestCodec_Read(t *testing.T) {
+	gbp := accountingtest.NewCurrencyCode(t, "GBP")
+	a := accountingtest.NewAccount(t, "Current", gbp, accountingtest.Date(2018, 1, 1))
+	const file = `!Type:Bank
+D1/ 1'18
+T1,000.00
+POpening Balance
+^
+D1/15'18
+T-45.5
+PSupermarket
+MGroceries
+LFood
+^
+
+D2/ 1'18
+T2500
+PSalary
+^
+`
+	c, err := qif.New("1/2/06")
+	common.FatalIfError(t, err, "creating Codec")
+	bs, err := c.Read(strings.NewReader(file), *a)
+	common.FatalIfError(t, err, "reading QIF")
+	assert.Equal(t, balance.Balances{
+		{Date: accountingtest.Date(2018, 1, 1), Amount: 100000, Currency: gbp},
+		{Date: accountingtest.Date(2018, 1, 15), Amount: 95450, Currency: gbp},
+		{Date: accountingtest.Date(2018, 2, 1), Amount: 345450, Currency: gbp},
+	}, bs)
+
+	c, err = qif.New("02/01/2006")
+	common.FatalIfError(t, err, "creating Codec")
+	bs, err = c.Read(strings.NewReader("!Type:Bank\r\nD02/01/2018\r\nT1.00\r\n^\r\n"), *a)
+	common.FatalIfError(t, err, "reading QIF with day first layout")
+	assert.Equal(t, balance.Balances{{Date: accountingtest.Date(2018, 1, 2), Amount: 100, Currency: gbp}}, bs)
+}
+
+func TestCodec_Read_Invalid(t *testing.T) {
+	a := accountingtest.NewAccount(t, "Current", accountingtest.NewCurrencyCode(t, "GBP"), accountingtest.Date(2018, 1, 1))
+	c, err := qif.New("01/02/2006")
+	common.FatalIfError(t, err, "creating Codec")
+	for _, test := range []struct {
+		name  string
+		file  string
+		cause string
+	}{
+		{name: "empty", cause: qif.ErrUnsupportedType},
+		{name: "unsupported type", file: "!Type:Invst\n", cause: qif.ErrUnsupportedType},
+		{name: "second header", file: "!Type:Bank\n!Account\n", cause: qif.ErrUnsupportedType},
+		{name: "missing date", file: "!Type:Bank\nT1\n^\n", cause: qif.ErrMissingDate},
+		{name: "missing amount", file: "!Type:Bank\nD01/01/2018\n^\n", cause: qif.ErrMissingAmount},
+		{name: "unterminated", file: "!Type:Bank\nD01/01/2018\nT1\n", cause: qif.ErrUnterminatedEntry},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			bs, err := c.Read(strings.NewReader(test.file), *a)
+			assert.Equal(t, test.cause, errors.Cause(err).Error())
+			assert.Nil(t, bs)
+		})
+	}
+
+	for _, file := range []string{
+		"!Type:Bank\nD2018-01-01\nT1\n^\n",
+		"!Type:Bank\nD01/01/2018\nTten\n^\n",
+	} {
+		_, err := c.Read(strings.NewReader(file), *a)
+		assert.Error(t, err, file)
+	}
+
+	_, err = c.Read(strings.NewReader("!Type:Bank\nD01/01/2017\nT1\n^\n"), *a)
+	assert.IsType(t, balance.DateOutOfAccountTimeRange{}, errors.Cause(err))
+}
+
+func TestCodec_RoundTrip(t *testing.T) {
+	gbp := accountingtest.NewCurrencyCode(t, "GBP")
+	a := accountingtest.NewAccount(t, "Current", gbp, accountingtest.Date(2018, 1, 1), account.CloseTime(accountingtest.Date(2019, 1, 1)))
+	bs := balance.Balances{
+		{Date: accountingtest.Date(2018, 1, 1), Amount: -5, Currency: gbp},
+		{Date: accountingtest.Date(2018, 3, 1), Amount: 123456},
+		{Date: accountingtest.Date(2018, 3, 1), Amount: 123400},
+		{Date: accountingtest.Date(2019, 1, 1), Amount: 0, Currency: gbp},
+	}
+	c, err := qif.New("01/02'06")
+	common.FatalIfError(t, err, "creating Codec")
+
+	var buf bytes.Buffer
+	common.FatalIfError(t, c.Write(&buf, *a, bs), "writing QIF")
+	assert.Equal(t, `!Type:Bank
+D01/01'18
+T-0.05
+^
+D03/01'18
+T1234.61
+^
+D03/01'18
+T-0.56
+^
+D01/01'19
+T-1234.00
+^
+`, buf.String())
+
+	read, err := c.Read(&buf, *a)
+	common.FatalIfError(t, err, "reading QIF")
+	if assert.Len(t, read, len(bs)) {
+		for i := range bs {
+			assert.Equal(t, bs[i].Date, read[i].Date)
+			assert.Equal(t, bs[i].Amount, read[i].Amount)
+			assert.Equal(t, gbp, read[i].Currency)
+		}
+	}
+
+	err = c.Write(&buf, *a, balance.Balances{{Date: accountingtest.Date(2017, 1, 1)}})
+	assert.IsType(t, balance.DateOutOfAccountTimeRange{}, errors.Cause(err))
+}
+
+func TestCodec_Write_InexactDate(t *testing.T) {
+	a := accountingtest.NewAccount(t, "Current", accountingtest.NewCurrencyCode(t, "GBP"), accountingtest.Date(2018, 1, 1))
+	c, err := qif.New("01/02/2006", qif.Location(time.FixedZone("TEST", -3*60*60)))
+	common.FatalIfError(t, err, "creating Codec")
+	for _, test := range []struct {
+		name string
+		balance.Balance
+	}{
+		{
+			name:    "time of day",
+			Balance: balance.Balance{Date: accountingtest.Date(2018, 1, 2).Add(12 * time.Hour)},
+		},
+		{
+			name:    "midnight in another location",
+			Balance: balance.Balance{Date: accountingtest.Date(2018, 1, 2)},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			err := c.Write(&buf, *a, balance.Balances{test.Balance})
+			assert.Equal(t, qif.ErrInexactDate, errors.Cause(err).Error())
+			assert.Zero(t, buf.Len())
+		})
+	}
+
+	withTime, err := qif.New("01/02/2006 15:04")
+	common.FatalIfError(t, err, "creating Codec")
+	var buf bytes.Buffer
+	bs := balance.Balances{
+		{Date: accountingtest.Date(2018, 1, 2).Add(9 * time.Hour), Amount: 1},
+		{Date: accountingtest.Date(2018, 1, 2).Add(17 * time.Hour), Amount: 2},
+	}
+	common.FatalIfError(t, withTime.Write(&buf, *a, bs), "writing QIF")
+	read, err := withTime.Read(&buf, *a)
+	common.FatalIfError(t, err, "reading QIF")
+	if assert.Len(t, read, len(bs)) {
+		for i := range bs {
+			assert.True(t, bs[i].Date.Equal(read[i].Date))
+		}
+	}
+}
+
+func TestLocation(t *testing.T) {
+	location := time.FixedZone("TEST", -3*60*60)
+	a := accountingtest.NewAccount(t, "Current", accountingtest.NewCurrencyCode(t, "GBP"), accountingtest.Date(2018, 1, 1))
+	c, err := qif.New("01/02/2006", qif.Location(location), qif.DecimalPlaces(0))
+	common.FatalIfError(t, err, "creating Codec")
+	bs, err := c.Read(strings.NewReader("!Type:Bank\nD01/02/2018\nT150\n^\n"), *a)
+	common.FatalIfError(t, err, "reading QIF")
+	assert.True(t, bs[0].Date.Equal(time.Date(2018, 1, 2, 0, 0, 0, 0, location)))
+	assert.Equal(t, 150, bs[0].Amount)
+}
@@ -0,0 +1,58 @@
+package reconcile
+
+import "fmt"
+
+// Side identifies which of the two Balances series being reconciled a Balance
+// belongs to.
+type Side string
+
+// The two sides of a reconciliation.
+const (
+	Recorded  Side = "recorded"
+	Statement Side = "statement"
+)
+
+// InvalidBalanceError is returned when a Balance of either series is invalid
+// for the Account being reconciled.
+// Err holds the error returned by Account.ValidateBalance.
+type InvalidBalanceError struct {
+	Side  Side
+	Index int
+	Err   error
+}
+
+// Error ensures that InvalidBalanceError adheres to the error interface.
+func (e InvalidBalanceError) Error() string {
+	return fmt.Sprintf("invalid %s Balance at index %d: %v", e.Side, e.Index, e.Err)
+}
+
+// UnreconciledError is returned alongside a Report when the two series do not
+// reconcile, summarising the problems found.
+type UnreconciledError struct {
+	Mismatches           int
+	MissingFromStatement int
+	MissingFromRecorded  int
+	Discrepancy          int
+}
+
+// Error ensures that UnreconciledError adheres to the error interface.
+func (e UnreconciledError) Error() string {
+	return fmt.Sprintf(
+		"Balances do not reconcile: %d mismatched, %d missing from statement, %d missing from recorded, discrepancy of %d",
+		e.Mismatches, e.MissingFromStatement, e.MissingFromRecorded, e.Discrepancy,
+	)
+}
+
+// InvalidTransactionError is returned when a Transaction of either side
+// cannot be posted to a Ledger of the Account being matched.
+// Err holds the error returned by Ledger.Post.
+type InvalidTransactionError struct {
+	Side  Side
+	Index int
+	Err   error
+}
+
+// Error ensures that InvalidTransactionError adheres to the error interface.
+func (e InvalidTransactionError) Error() string {
+	return fmt.Sprintf("invalid %s Transaction at index %d: %v", e.Side, e.Index, e.Err)
+}
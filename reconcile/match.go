@@ -0,0 +1,387 @@
+package reconcile
+
+import (
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/glynternet/go-accounting/account"
+	"github.com/glynternet/go-accounting/transaction"
+)
+
+// Various error messages describing possible errors when creating a Matcher.
+const (
+	ErrNegativeTolerance = "tolerance must not be negative"
+	ErrInvalidMaxParts   = "max parts must be at least 2"
+)
+
+// Option is a function that configures a Matcher.
+type Option func(*Matcher) error
+
+// DateTolerance is an Option that sets how far apart the Dates of two
+// Transactions can be for them to match.
+func DateTolerance(d time.Duration) Option {
+	return func(m *Matcher) error {
+		if d < 0 {
+			return errors.New(ErrNegativeTolerance)
+		}
+		m.dateTolerance = d
+		return nil
+	}
+}
+
+// AmountTolerance is an Option that sets how far apart the Amounts of two
+// Transactions can be for them to match.
+func AmountTolerance(n int) Option {
+	return func(m *Matcher) error {
+		if n < 0 {
+			return errors.New(ErrNegativeTolerance)
+		}
+		m.amountTolerance = n
+		return nil
+	}
+}
+
+// MaxParts is an Option that sets the greatest number of Transactions that a
+// single Transaction can be split into.
+func MaxParts(n int) Option {
+	return func(m *Matcher) error {
+		if n < 2 {
+			return errors.New(ErrInvalidMaxParts)
+		}
+		m.maxParts = n
+		return nil
+	}
+}
+
+// NewMatcher creates a new Matcher.
+// By default, a Matcher matches Transactions with exactly equal Dates and
+// Amounts and finds splits of up to 3 parts.
+func NewMatcher(os ...Option) (*Matcher, error) {
+	m := &Matcher{maxParts: 3}
+	for _, o := range os {
+		if err := o(m); err != nil {
+			return nil, err
+		}
+	}
+	return m, nil
+}
+
+// Matcher pairs the Transactions recorded for an Account with the lines of
+// its bank statement.
+type Matcher struct {
+	dateTolerance   time.Duration
+	amountTolerance int
+	maxParts        int
+}
+
+// Pair is a recorded Transaction matched with a statement Transaction.
+// The differences are those of the statement Transaction less the recorded
+// Transaction.
+type Pair struct {
+	Recorded         transaction.Transaction
+	Statement        transaction.Transaction
+	DateDifference   time.Duration
+	AmountDifference int
+}
+
+// Split is a single Transaction on one Side that matches several Parts on the
+// other Side, such as a single card payment recorded as separate purchases.
+type Split struct {
+	Side        Side
+	Transaction transaction.Transaction
+	Parts       transaction.Transactions
+}
+
+// Duplicate is a group of Transactions on one Side with equal Dates, Amounts
+// and Descriptions.
+type Duplicate struct {
+	Side         Side
+	Transactions transaction.Transactions
+}
+
+// Worksheet holds the outcome of matching the Transactions of an Account.
+type Worksheet struct {
+	Account            account.Account
+	Pairs              []Pair
+	Splits             []Split
+	Duplicates         []Duplicate
+	UnmatchedRecorded  transaction.Transactions
+	UnmatchedStatement transaction.Transactions
+}
+
+// Reconciled returns true if every Transaction on both Sides was matched.
+func (w Worksheet) Reconciled() bool {
+	return len(w.UnmatchedRecorded) == 0 && len(w.UnmatchedStatement) == 0
+}
+
+// Match matches the recorded Transactions of an Account with its statement
+// Transactions, producing a Worksheet.
+// Transactions are first paired one to one, preferring the closest Amounts
+// and then the closest Dates. The remaining Transactions on each Side are
+// then matched with Splits of the other Side, whose Parts all have Amounts
+// of the same sign as the split Transaction, are all within the date
+// tolerance of it and have a total within the amount tolerance of its Amount.
+// The search for Splits is bounded, so a Split hidden among very many
+// candidate Transactions may be missed. Anything left is unmatched.
+// Duplicates are reported for review but are still matched, as genuine
+// repeated Transactions are common.
+// Every Transaction must be valid to post to a Ledger of the Account,
+// otherwise an InvalidTransactionError is returned.
+func (m Matcher) Match(a account.Account, recorded, statement transaction.Transactions) (Worksheet, error) {
+	for _, side := range []struct {
+		Side
+		transaction.Transactions
+	}{{Recorded, recorded}, {Statement, statement}} {
+		l, err := transaction.NewLedger(a)
+		if err != nil {
+			return Worksheet{}, err
+		}
+		for i, t := range side.Transactions {
+			if err := l.Post(t); err != nil {
+				return Worksheet{}, InvalidTransactionError{Side: side.Side, Index: i, Err: err}
+			}
+		}
+	}
+
+	w := Worksheet{
+		Account:    a,
+		Duplicates: append(duplicates(Recorded, recorded), duplicates(Statement, statement)...),
+	}
+	rs, ss := recorded.Sorted(), statement.Sorted()
+	rUsed, sUsed := make([]bool, len(rs)), make([]bool, len(ss))
+
+	for _, c := range m.candidates(rs, ss) {
+		if rUsed[c.r] || sUsed[c.s] {
+			continue
+		}
+		rUsed[c.r], sUsed[c.s] = true, true
+		w.Pairs = append(w.Pairs, Pair{
+			Recorded:         rs[c.r],
+			Statement:        ss[c.s],
+			DateDifference:   ss[c.s].Date.Sub(rs[c.r].Date),
+			AmountDifference: ss[c.s].Amount - rs[c.r].Amount,
+		})
+	}
+
+	w.Splits = append(w.Splits, m.splits(Statement, ss, sUsed, rs, rUsed)...)
+	w.Splits = append(w.Splits, m.splits(Recorded, rs, rUsed, ss, sUsed)...)
+
+	w.UnmatchedRecorded = unused(rs, rUsed)
+	w.UnmatchedStatement = unused(ss, sUsed)
+	return w, nil
+}
+
+type candidate struct {
+	r, s       int
+	amountDiff int
+	dateDiff   time.Duration
+}
+
+// candidates returns every pair of recorded and statement Transactions that
+// are within tolerance of each other, closest first.
+func (m Matcher) candidates(rs, ss transaction.Transactions) []candidate {
+	var cs []candidate
+	for i, r := range rs {
+		for j, s := range ss {
+			c := candidate{r: i, s: j, amountDiff: abs(s.Amount - r.Amount), dateDiff: absDuration(s.Date.Sub(r.Date))}
+			if c.amountDiff <= m.amountTolerance && c.dateDiff <= m.dateTolerance {
+				cs = append(cs, c)
+			}
+		}
+	}
+	sort.SliceStable(cs, func(i, j int) bool {
+		if cs[i].amountDiff != cs[j].amountDiff {
+			return cs[i].amountDiff < cs[j].amountDiff
+		}
+		return cs[i].dateDiff < cs[j].dateDiff
+	})
+	return cs
+}
+
+// The split search is bounded so that Match stays fast on long statements
+// with wide date tolerances.
+const (
+	// maxSplitCandidates is the greatest number of Transactions considered
+	// as the Parts of a single Split. When more are within the date
+	// tolerance, those closest in Date are used.
+	maxSplitCandidates = 32
+	// maxSplitSteps is the greatest number of partial combinations tried
+	// when searching for the Parts of a single Split.
+	maxSplitSteps = 1 << 16
+)
+
+// splits matches each unused Transaction of ones with a combination of unused
+// Transactions of parts, marking those matched as used.
+func (m Matcher) splits(side Side, ones transaction.Transactions, onesUsed []bool, parts transaction.Transactions, partsUsed []bool) []Split {
+	var splits []Split
+	for i, one := range ones {
+		if onesUsed[i] {
+			continue
+		}
+		combination := m.combination(one, parts, m.splitCandidates(one, parts, partsUsed))
+		if combination == nil {
+			continue
+		}
+		onesUsed[i] = true
+		s := Split{Side: side, Transaction: one}
+		for _, j := range combination {
+			partsUsed[j] = true
+			s.Parts = append(s.Parts, parts[j])
+		}
+		splits = append(splits, s)
+	}
+	return splits
+}
+
+// splitCandidates returns the indices of the unused parts that could make up
+// a Split of one: those with an Amount of the same sign and a Date within the
+// date tolerance. At most maxSplitCandidates are returned, closest in Date
+// first, and they are returned in ascending order of Amount size.
+func (m Matcher) splitCandidates(one transaction.Transaction, parts transaction.Transactions, used []bool) []int {
+	var near []int
+	for j, p := range parts {
+		if used[j] || (p.Amount > 0) != (one.Amount > 0) || p.Amount == 0 || one.Amount == 0 {
+			continue
+		}
+		if absDuration(p.Date.Sub(one.Date)) <= m.dateTolerance {
+			near = append(near, j)
+		}
+	}
+	if len(near) > maxSplitCandidates {
+		sort.SliceStable(near, func(a, b int) bool {
+			return absDuration(parts[near[a]].Date.Sub(one.Date)) < absDuration(parts[near[b]].Date.Sub(one.Date))
+		})
+		near = near[:maxSplitCandidates]
+	}
+	sort.SliceStable(near, func(a, b int) bool {
+		return abs(parts[near[a]].Amount) < abs(parts[near[b]].Amount)
+	})
+	return near
+}
+
+// combination returns the first combination, smallest first, of between 2
+// and maxParts of the indexed Transactions whose total is within the amount
+// tolerance of the Amount of one. The indices must all have Amounts of the
+// same sign as one, in ascending order of size. The returned indices are in
+// ascending order, so that Parts keep the order of the Transactions.
+func (m Matcher) combination(one transaction.Transaction, ts transaction.Transactions, indices []int) []int {
+	s := splitSearch{
+		target:    abs(one.Amount),
+		tolerance: m.amountTolerance,
+		amounts:   make([]int, len(indices)),
+		totals:    make([]int, len(indices)+1),
+	}
+	for k, i := range indices {
+		s.amounts[k] = abs(ts[i].Amount)
+		s.totals[k+1] = s.totals[k] + s.amounts[k]
+	}
+	for size := 2; size <= m.maxParts && size <= len(indices); size++ {
+		chosen := s.find(0, size, 0, nil)
+		if chosen == nil {
+			if s.steps > maxSplitSteps {
+				return nil
+			}
+			continue
+		}
+		c := make([]int, len(chosen))
+		for n, k := range chosen {
+			c[n] = indices[k]
+		}
+		sort.Ints(c)
+		return c
+	}
+	return nil
+}
+
+// splitSearch finds a combination of amounts, held in ascending order, whose
+// total is within tolerance of the target.
+// totals holds the running totals of amounts, so that the largest possible
+// total of the remaining amounts can be found without summing them.
+type splitSearch struct {
+	target    int
+	tolerance int
+	amounts   []int
+	totals    []int
+	steps     int
+}
+
+// find returns the positions of need more amounts, from start onwards, that
+// bring sum within tolerance of the target.
+// As the amounts are in ascending order, a branch is abandoned as soon as
+// its smallest possible total is too large, and skipped when its largest
+// possible total is too small.
+func (s *splitSearch) find(start, need, sum int, chosen []int) []int {
+	if need == 0 {
+		if abs(s.target-sum) <= s.tolerance {
+			return append([]int(nil), chosen...)
+		}
+		return nil
+	}
+	n := len(s.amounts)
+	largest := s.totals[n] - s.totals[n-need+1]
+	for k := start; k <= n-need; k++ {
+		if s.steps++; s.steps > maxSplitSteps {
+			return nil
+		}
+		next := sum + s.amounts[k]
+		if next+(need-1)*s.amounts[k] > s.target+s.tolerance {
+			return nil
+		}
+		if next+largest < s.target-s.tolerance {
+			continue
+		}
+		if c := s.find(k+1, need-1, next, append(chosen, k)); c != nil {
+			return c
+		}
+	}
+	return nil
+}
+
+// duplicates returns the groups of Transactions that have equal Dates,
+// Amounts and Descriptions.
+func duplicates(side Side, ts transaction.Transactions) []Duplicate {
+	var ds []Duplicate
+	grouped := make([]bool, len(ts))
+	for i, t := range ts {
+		if grouped[i] {
+			continue
+		}
+		d := Duplicate{Side: side, Transactions: transaction.Transactions{t}}
+		for j := i + 1; j < len(ts); j++ {
+			o := ts[j]
+			if !grouped[j] && o.Date.Equal(t.Date) && o.Amount == t.Amount && o.Description == t.Description {
+				grouped[j] = true
+				d.Transactions = append(d.Transactions, o)
+			}
+		}
+		if len(d.Transactions) > 1 {
+			ds = append(ds, d)
+		}
+	}
+	return ds
+}
+
+func unused(ts transaction.Transactions, used []bool) transaction.Transactions {
+	var un transaction.Transactions
+	for i, t := range ts {
+		if !used[i] {
+			un = append(un, t)
+		}
+	}
+	return un
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+func absDuration(d time.Duration) time.Duration {
+	if d < 0 {
+		return -d
+	}
+	return d
+}
@@ -0,0 +1,198 @@
+package reconcile_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/glynternet/go-accounting/account"
+	"github.com/glynternet/go-accounting/accountingtest"
+	"github.com/glynternet/go-accounting/reconcile"
+	"github.com/glynternet/go-accounting/transaction"
+	"github.com/glynternet/go-money/currency"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewMatcher(t *testing.T) {
+	for _, test := range []struct {
+		name string
+		reconcile.Option
+		err error
+	}{
+		{name: "date tolerance", Option: reconcile.DateTolerance(time.Hour)},
+		{name: "negative date tolerance", Option: reconcile.DateTolerance(-1), err: errors.New(reconcile.ErrNegativeTolerance)},
+		{name: "amount tolerance", Option: reconcile.AmountTolerance(1)},
+		{name: "negative amount tolerance", Option: reconcile.AmountTolerance(-1), err: errors.New(reconcile.ErrNegativeTolerance)},
+		{name: "max parts", Option: reconcile.MaxParts(2)},
+		{name: "invalid max parts", Option: reconcile.MaxParts(1), err: errors.New(reconcile.ErrInvalidMaxParts)},
+	} {
+		m, err := reconcile.NewMatcher(test.Option)
+		assert.Equal(t, test.err, err, test.name)
+		assert.Equal(t, test.err == nil, m != nil, test.name)
+	}
+}
+
+func TestMatcher_Match(t *testing.T) {
+	a := *accountingtest.NewAccount(t, "Current", accountingtest.NewCurrencyCode(t, "GBP"), accountingtest.Date(2020, 1, 1))
+	recorded := transaction.Transactions{
+		{Date: accountingtest.Date(2020, 1, 2), Amount: -1000, Description: "rent"},
+		{Date: accountingtest.Date(2020, 1, 3), Amount: -250, Description: "shop"},
+		{Date: accountingtest.Date(2020, 1, 3), Amount: -250, Description: "shop"},
+		{Date: accountingtest.Date(2020, 1, 10), Amount: -30, Description: "books"},
+		{Date: accountingtest.Date(2020, 1, 10), Amount: -20, Description: "pens"},
+		{Date: accountingtest.Date(2020, 1, 15), Amount: -500, Description: "holiday"},
+		{Date: accountingtest.Date(2020, 1, 20), Amount: -99, Description: "gym"},
+	}
+	statement := transaction.Transactions{
+		{Date: accountingtest.Date(2020, 1, 3), Amount: -1000, Description: "SO RENT"},
+		{Date: accountingtest.Date(2020, 1, 4), Amount: -251, Description: "SHOP"},
+		{Date: accountingtest.Date(2020, 1, 3), Amount: -250, Description: "SHOP"},
+		{Date: accountingtest.Date(2020, 1, 11), Amount: -50, Description: "STATIONERS"},
+		{Date: accountingtest.Date(2020, 1, 15), Amount: -300, Description: "TRAVEL"},
+		{Date: accountingtest.Date(2020, 1, 16), Amount: -200, Description: "HOTEL"},
+		{Date: accountingtest.Date(2020, 1, 25), Amount: -12, Description: "FEE"},
+	}
+	m, err := reconcile.NewMatcher(reconcile.DateTolerance(48*time.Hour), reconcile.AmountTolerance(1))
+	assert.Nil(t, err)
+
+	w, err := m.Match(a, recorded, statement)
+	assert.Nil(t, err)
+	assert.Equal(t, reconcile.Worksheet{
+		Account: a,
+		Pairs: []reconcile.Pair{
+			{Recorded: recorded[1], Statement: statement[2]},
+			{Recorded: recorded[0], Statement: statement[0], DateDifference: 24 * time.Hour},
+			{Recorded: recorded[2], Statement: statement[1], DateDifference: 24 * time.Hour, AmountDifference: -1},
+		},
+		Splits: []reconcile.Split{
+			{Side: reconcile.Statement, Transaction: statement[3], Parts: transaction.Transactions{recorded[3], recorded[4]}},
+			{Side: reconcile.Recorded, Transaction: recorded[5], Parts: transaction.Transactions{statement[4], statement[5]}},
+		},
+		Duplicates: []reconcile.Duplicate{
+			{Side: reconcile.Recorded, Transactions: transaction.Transactions{recorded[1], recorded[2]}},
+		},
+		UnmatchedRecorded:  transaction.Transactions{recorded[6]},
+		UnmatchedStatement: transaction.Transactions{statement[6]},
+	}, w)
+	assert.False(t, w.Reconciled())
+}
+
+func TestMatcher_Match_Exact(t *testing.T) {
+	a := *accountingtest.NewAccount(t, "Current", accountingtest.NewCurrencyCode(t, "GBP"), accountingtest.Date(2020, 1, 1))
+	m, err := reconcile.NewMatcher()
+	assert.Nil(t, err)
+
+	recorded := transaction.Transactions{{Date: accountingtest.Date(2020, 1, 2), Amount: -10}}
+	w, err := m.Match(a, recorded, transaction.Transactions{{Date: accountingtest.Date(2020, 1, 2), Amount: -10}})
+	assert.Nil(t, err)
+	assert.True(t, w.Reconciled())
+	assert.Len(t, w.Pairs, 1)
+
+	w, err = m.Match(a, recorded, transaction.Transactions{{Date: accountingtest.Date(2020, 1, 3), Amount: -10}})
+	assert.Nil(t, err)
+	assert.False(t, w.Reconciled())
+	assert.Empty(t, w.Pairs)
+}
+
+func TestMatcher_Match_MaxParts(t *testing.T) {
+	a := *accountingtest.NewAccount(t, "Current", accountingtest.NewCurrencyCode(t, "GBP"), accountingtest.Date(2020, 1, 1))
+	parts := transaction.Transactions{
+		{Date: accountingtest.Date(2020, 1, 2), Amount: -1},
+		{Date: accountingtest.Date(2020, 1, 2), Amount: -2},
+		{Date: accountingtest.Date(2020, 1, 2), Amount: -3},
+	}
+	whole := transaction.Transactions{{Date: accountingtest.Date(2020, 1, 2), Amount: -6}}
+
+	m, err := reconcile.NewMatcher(reconcile.MaxParts(2))
+	assert.Nil(t, err)
+	w, err := m.Match(a, parts, whole)
+	assert.Nil(t, err)
+	assert.Empty(t, w.Splits)
+
+	m, err = reconcile.NewMatcher()
+	assert.Nil(t, err)
+	w, err = m.Match(a, parts, whole)
+	assert.Nil(t, err)
+	assert.Equal(t, []reconcile.Split{{Side: reconcile.Statement, Transaction: whole[0], Parts: parts}}, w.Splits)
+	assert.True(t, w.Reconciled())
+}
+
+func TestMatcher_Match_InvalidTransaction(t *testing.T) {
+	a := *accountingtest.NewAccount(t, "Current", accountingtest.NewCurrencyCode(t, "GBP"), accountingtest.Date(2020, 1, 1))
+	other := *accountingtest.NewAccount(t, "Other", accountingtest.NewCurrencyCode(t, "GBP"), accountingtest.Date(2020, 1, 1), account.OfType(account.Asset))
+	m, err := reconcile.NewMatcher()
+	assert.Nil(t, err)
+
+	_, err = m.Match(a, nil, transaction.Transactions{{Date: accountingtest.Date(2020, 1, 2)}, {Date: accountingtest.Date(2020, 1, 2), Account: &other}})
+	assert.Equal(t, reconcile.InvalidTransactionError{
+		Side:  reconcile.Statement,
+		Index: 1,
+		Err:   errors.New(transaction.ErrAccountMismatch),
+	}, err)
+	assert.EqualError(t, err, "invalid statement Transaction at index 1: "+transaction.ErrAccountMismatch)
+}
+
+// newScaleTest returns a month of recorded and statement Transactions where
+// few lines pair one to one, so that with a wide date tolerance the split
+// search is exercised for almost every line.
+func newScaleTest(n int) (transaction.Transactions, transaction.Transactions) {
+	var recorded, statement transaction.Transactions
+	for i := 0; i < n; i++ {
+		d := accountingtest.Date(2020, 1, 1+i%31)
+		recorded = append(recorded, transaction.Transaction{Date: d, Amount: -(1000 + 7*i)})
+		statement = append(statement, transaction.Transaction{Date: d, Amount: -(100003 + 11*i)})
+		if i%2 == 0 {
+			statement = append(statement, transaction.Transaction{Date: d, Amount: 50 + i})
+		}
+	}
+	return recorded, statement
+}
+
+func TestMatcher_Match_Scale(t *testing.T) {
+	a := *accountingtest.NewAccount(t, "Current", accountingtest.NewCurrencyCode(t, "GBP"), accountingtest.Date(2020, 1, 1))
+	recorded, statement := newScaleTest(300)
+	m, err := reconcile.NewMatcher(reconcile.DateTolerance(31*24*time.Hour), reconcile.AmountTolerance(1))
+	assert.Nil(t, err)
+
+	w, err := m.Match(a, recorded, statement)
+	assert.Nil(t, err)
+	for _, s := range w.Splits {
+		var total int
+		for _, p := range s.Parts {
+			assert.True(t, (p.Amount > 0) == (s.Transaction.Amount > 0), "split parts should share the sign of the split Transaction")
+			total += p.Amount
+		}
+		assert.InDelta(t, s.Transaction.Amount, total, 1)
+	}
+	matched := len(w.Pairs) + len(w.UnmatchedRecorded)
+	for _, s := range w.Splits {
+		if s.Side == reconcile.Recorded {
+			matched++
+		} else {
+			matched += len(s.Parts)
+		}
+	}
+	assert.Equal(t, len(recorded), matched, "every recorded Transaction should be accounted for once")
+}
+
+func BenchmarkMatcher_Match(b *testing.B) {
+	gbp, err := currency.NewCode("GBP")
+	if err != nil {
+		b.Fatal(err)
+	}
+	a, err := account.New("Current", *gbp, accountingtest.Date(2020, 1, 1))
+	if err != nil {
+		b.Fatal(err)
+	}
+	recorded, statement := newScaleTest(300)
+	m, err := reconcile.NewMatcher(reconcile.DateTolerance(31*24*time.Hour), reconcile.AmountTolerance(1))
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := m.Match(*a, recorded, statement); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
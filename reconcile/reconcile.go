@@ -0,0 +1,115 @@
+// Package reconcile compares the Balances and Transactions recorded for an
+// Account against those given by its bank statements.
+package reconcile
+
+import (
+	"time"
+
+	"github.com/glynternet/go-accounting/account"
+	"github.com/glynternet/go-accounting/balance"
+)
+
+// Mismatch describes a Date at which the recorded and statement Balances
+// have different Amounts.
+// Difference is the statement Amount less the recorded Amount. Change is how
+// much the Difference has changed since the previous Date present in both
+// series, which is where the discrepancy was introduced.
+type Mismatch struct {
+	Date       time.Time
+	Recorded   int
+	Statement  int
+	Difference int
+	Change     int
+}
+
+// Report holds the outcome of reconciling two Balances series.
+// Matched is the number of Dates present in both series with equal Amounts.
+// Discrepancy is the Difference at the latest Date present in both series,
+// which, as Balances are cumulative, is the total discrepancy between them.
+type Report struct {
+	Matched              int
+	Mismatches           []Mismatch
+	MissingFromStatement balance.Balances
+	MissingFromRecorded  balance.Balances
+	Discrepancy          int
+}
+
+// Reconciled returns true if the Report found no Mismatches and no Dates
+// missing from either series.
+func (r Report) Reconciled() bool {
+	return len(r.Mismatches) == 0 && len(r.MissingFromStatement) == 0 && len(r.MissingFromRecorded) == 0
+}
+
+// Reconcile aligns the recorded and statement Balances of an Account by Date
+// and reports where they differ.
+// Where a series has several Balances with the same Date, the one encountered
+// last is used, as with Balances.AtTime.
+// Reconcile returns an InvalidBalanceError, and no Report, if any Balance is
+// invalid for the Account. If the series do not reconcile, the full Report is
+// returned along with an UnreconciledError summarising it.
+func Reconcile(a account.Account, recorded, statement balance.Balances) (Report, error) {
+	for _, series := range []struct {
+		Side
+		balance.Balances
+	}{{Recorded, recorded}, {Statement, statement}} {
+		for i, b := range series.Balances {
+			if err := a.ValidateBalance(b); err != nil {
+				return Report{}, InvalidBalanceError{Side: series.Side, Index: i, Err: err}
+			}
+		}
+	}
+
+	rs, ss := lastByDate(recorded), lastByDate(statement)
+	var r Report
+	var previous int
+	for i, j := 0, 0; i < len(rs) || j < len(ss); {
+		switch {
+		case j == len(ss) || i < len(rs) && rs[i].Date.Before(ss[j].Date):
+			r.MissingFromStatement = append(r.MissingFromStatement, rs[i])
+			i++
+		case i == len(rs) || ss[j].Date.Before(rs[i].Date):
+			r.MissingFromRecorded = append(r.MissingFromRecorded, ss[j])
+			j++
+		default:
+			difference := ss[j].Amount - rs[i].Amount
+			if difference == 0 {
+				r.Matched++
+			} else {
+				r.Mismatches = append(r.Mismatches, Mismatch{
+					Date:       rs[i].Date,
+					Recorded:   rs[i].Amount,
+					Statement:  ss[j].Amount,
+					Difference: difference,
+					Change:     difference - previous,
+				})
+			}
+			previous = difference
+			r.Discrepancy = difference
+			i++
+			j++
+		}
+	}
+	if r.Reconciled() {
+		return r, nil
+	}
+	return r, UnreconciledError{
+		Mismatches:           len(r.Mismatches),
+		MissingFromStatement: len(r.MissingFromStatement),
+		MissingFromRecorded:  len(r.MissingFromRecorded),
+		Discrepancy:          r.Discrepancy,
+	}
+}
+
+// lastByDate returns the Balances in Date order, keeping only the last
+// encountered Balance of each Date.
+func lastByDate(bs balance.Balances) balance.Balances {
+	sorted := balance.NewSorted(bs).Balances()
+	var last balance.Balances
+	for i, b := range sorted {
+		if i+1 < len(sorted) && sorted[i+1].Date.Equal(b.Date) {
+			continue
+		}
+		last = append(last, b)
+	}
+	return last
+}
@@ -0,0 +1,91 @@
+package reconcile_test
+
+import (
+	"testing"
+
+	"github.com/glynternet/go-accounting/accountingtest"
+	"github.com/glynternet/go-accounting/balance"
+	"github.com/glynternet/go-accounting/reconcile"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReconcile(t *testing.T) {
+	a := *accountingtest.NewAccount(t, "Current", accountingtest.NewCurrencyCode(t, "GBP"), accountingtest.Date(2020, 1, 1))
+	recorded := balance.Balances{
+		{Date: accountingtest.Date(2020, 1, 1), Amount: 100},
+		{Date: accountingtest.Date(2020, 1, 2), Amount: 150},
+		{Date: accountingtest.Date(2020, 1, 3), Amount: 999},
+		{Date: accountingtest.Date(2020, 1, 3), Amount: 200},
+		{Date: accountingtest.Date(2020, 1, 5), Amount: 250},
+		{Date: accountingtest.Date(2020, 1, 6), Amount: 300},
+	}
+	statement := balance.Balances{
+		{Date: accountingtest.Date(2020, 1, 7), Amount: 270},
+		{Date: accountingtest.Date(2020, 1, 1), Amount: 100},
+		{Date: accountingtest.Date(2020, 1, 2), Amount: 140},
+		{Date: accountingtest.Date(2020, 1, 3), Amount: 190},
+		{Date: accountingtest.Date(2020, 1, 4), Amount: 190},
+		{Date: accountingtest.Date(2020, 1, 6), Amount: 270},
+	}
+
+	r, err := reconcile.Reconcile(a, recorded, statement)
+	assert.Equal(t, reconcile.Report{
+		Matched: 1,
+		Mismatches: []reconcile.Mismatch{
+			{Date: accountingtest.Date(2020, 1, 2), Recorded: 150, Statement: 140, Difference: -10, Change: -10},
+			{Date: accountingtest.Date(2020, 1, 3), Recorded: 200, Statement: 190, Difference: -10, Change: 0},
+			{Date: accountingtest.Date(2020, 1, 6), Recorded: 300, Statement: 270, Difference: -30, Change: -20},
+		},
+		MissingFromStatement: balance.Balances{{Date: accountingtest.Date(2020, 1, 5), Amount: 250}},
+		MissingFromRecorded: balance.Balances{
+			{Date: accountingtest.Date(2020, 1, 4), Amount: 190},
+			{Date: accountingtest.Date(2020, 1, 7), Amount: 270},
+		},
+		Discrepancy: -30,
+	}, r)
+	assert.False(t, r.Reconciled())
+	assert.Equal(t, reconcile.UnreconciledError{
+		Mismatches:           3,
+		MissingFromStatement: 1,
+		MissingFromRecorded:  2,
+		Discrepancy:          -30,
+	}, err)
+	assert.EqualError(t, err, "Balances do not reconcile: 3 mismatched, 1 missing from statement, 2 missing from recorded, discrepancy of -30")
+}
+
+func TestReconcile_Reconciled(t *testing.T) {
+	a := *accountingtest.NewAccount(t, "Current", accountingtest.NewCurrencyCode(t, "GBP"), accountingtest.Date(2020, 1, 1))
+	bs := balance.Balances{
+		{Date: accountingtest.Date(2020, 1, 1), Amount: 100},
+		{Date: accountingtest.Date(2020, 1, 2), Amount: 150},
+	}
+	r, err := reconcile.Reconcile(a, bs, balance.Balances{bs[1], bs[0]})
+	assert.Nil(t, err)
+	assert.True(t, r.Reconciled())
+	assert.Equal(t, reconcile.Report{Matched: 2}, r)
+
+	r, err = reconcile.Reconcile(a, nil, nil)
+	assert.Nil(t, err)
+	assert.True(t, r.Reconciled())
+}
+
+func TestReconcile_InvalidBalance(t *testing.T) {
+	a := *accountingtest.NewAccount(t, "Current", accountingtest.NewCurrencyCode(t, "GBP"), accountingtest.Date(2020, 1, 1))
+	eur := accountingtest.NewCurrencyCode(t, "EUR")
+	_, err := reconcile.Reconcile(a,
+		balance.Balances{{Date: accountingtest.Date(2020, 1, 1)}},
+		balance.Balances{{Date: accountingtest.Date(2020, 1, 1)}, {Date: accountingtest.Date(2020, 1, 2), Currency: eur}},
+	)
+	assert.Equal(t, reconcile.InvalidBalanceError{
+		Side:  reconcile.Statement,
+		Index: 1,
+		Err:   balance.CurrencyMismatch{BalanceCurrency: eur, AccountCurrency: a.CurrencyCode()},
+	}, err)
+	assert.EqualError(t, err, "invalid statement Balance at index 1: Balance currency EUR does not match Account currency GBP.")
+
+	_, err = reconcile.Reconcile(a, balance.Balances{{Date: accountingtest.Date(2019, 1, 1)}}, nil)
+	if assert.IsType(t, reconcile.InvalidBalanceError{}, err) {
+		assert.Equal(t, reconcile.Recorded, err.(reconcile.InvalidBalanceError).Side)
+		assert.IsType(t, balance.DateOutOfAccountTimeRange{}, err.(reconcile.InvalidBalanceError).Err)
+	}
+}
@@ -0,0 +1,68 @@
+package rrule
+
+import (
+	"sort"
+	"time"
+
+	"github.com/glynternet/go-accounting/account"
+	"github.com/glynternet/go-accounting/balance"
+)
+
+// Amounts returns a Balance of the given amount, in the currency of the
+// Account, dated at each occurrence of the Rule up to and including until.
+// The Balances are clipped to the lifetime of the Account using
+// Account.ClipBalances.
+func (r Rule) Amounts(a account.Account, amount int, until time.Time) balance.Balances {
+	var amounts balance.Balances
+	for _, t := range r.Occurrences(a.Opened().Add(-time.Nanosecond), until) {
+		amounts = append(amounts, balance.Balance{Date: t, Amount: amount, Currency: a.CurrencyCode()})
+	}
+	return a.ClipBalances(amounts)
+}
+
+// Apply returns the Balances of an Account with the given dated amounts
+// added to them.
+// A Balance is returned for each distinct Date of the Balances and the
+// amounts, in Date order, holding the Amount given by Balances.AtTime plus
+// every amount dated at or before that Date. Where there is no Balance at or
+// before a Date, the amounts are added to zero.
+// Apply returns an error if any of the Balances or amounts are invalid for
+// the Account.
+func Apply(a account.Account, bs, amounts balance.Balances) (balance.Balances, error) {
+	for _, b := range append(append(balance.Balances(nil), bs...), amounts...) {
+		if err := a.ValidateBalance(b); err != nil {
+			return nil, err
+		}
+	}
+	sortedBalances := balance.NewSorted(bs)
+	sortedAmounts := balance.NewSorted(amounts).Balances()
+	var dates []time.Time
+	for _, b := range bs {
+		dates = append(dates, b.Date)
+	}
+	for _, b := range sortedAmounts {
+		dates = append(dates, b.Date)
+	}
+	sort.Slice(dates, func(i, j int) bool { return dates[i].Before(dates[j]) })
+
+	var applied balance.Balances
+	var added, next int
+	for i, d := range dates {
+		if i > 0 && d.Equal(dates[i-1]) {
+			continue
+		}
+		for ; next < len(sortedAmounts) && !sortedAmounts[next].Date.After(d); next++ {
+			added += sortedAmounts[next].Amount
+		}
+		var amount int
+		if b, err := sortedBalances.AtTime(d); err == nil {
+			amount = b.Amount
+		}
+		applied = append(applied, balance.Balance{
+			Date:     d,
+			Amount:   amount + added,
+			Currency: a.CurrencyCode(),
+		})
+	}
+	return applied, nil
+}
@@ -0,0 +1,48 @@
+package rrule_test
+
+import (
+	"testing"
+
+	"github.com/glynternet/go-accounting/account"
+	"github.com/glynternet/go-accounting/accountingtest"
+	"github.com/glynternet/go-accounting/balance"
+	"github.com/glynternet/go-accounting/rrule"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRule_Amounts(t *testing.T) {
+	gbp := accountingtest.NewCurrencyCode(t, "GBP")
+	a := *accountingtest.NewAccount(t, "Current", gbp, nineAM(2020, 1, 2), account.CloseTime(nineAM(2020, 1, 4)))
+	r, err := rrule.ParseRule(nineAM(2020, 1, 1), "FREQ=DAILY")
+	assert.Nil(t, err)
+	assert.Equal(t, balance.Balances{
+		{Date: nineAM(2020, 1, 2), Amount: -5, Currency: gbp},
+		{Date: nineAM(2020, 1, 3), Amount: -5, Currency: gbp},
+		{Date: nineAM(2020, 1, 4), Amount: -5, Currency: gbp},
+	}, r.Amounts(a, -5, nineAM(2021, 1, 1)))
+}
+
+func TestApply(t *testing.T) {
+	gbp := accountingtest.NewCurrencyCode(t, "GBP")
+	a := *accountingtest.NewAccount(t, "Current", gbp, nineAM(2020, 1, 1))
+	bs := balance.Balances{
+		{Date: nineAM(2020, 1, 3), Amount: 100, Currency: gbp},
+		{Date: nineAM(2020, 1, 1), Amount: 10, Currency: gbp},
+	}
+	amounts := balance.Balances{
+		{Date: nineAM(2020, 1, 4), Amount: 1000, Currency: gbp},
+		{Date: nineAM(2020, 1, 2), Amount: 1},
+		{Date: nineAM(2020, 1, 3), Amount: 2},
+	}
+	applied, err := rrule.Apply(a, bs, amounts)
+	assert.Nil(t, err)
+	assert.Equal(t, balance.Balances{
+		{Date: nineAM(2020, 1, 1), Amount: 10, Currency: gbp},
+		{Date: nineAM(2020, 1, 2), Amount: 11, Currency: gbp},
+		{Date: nineAM(2020, 1, 3), Amount: 103, Currency: gbp},
+		{Date: nineAM(2020, 1, 4), Amount: 1103, Currency: gbp},
+	}, applied)
+
+	_, err = rrule.Apply(a, bs, balance.Balances{{Date: nineAM(2019, 1, 1)}})
+	assert.IsType(t, balance.DateOutOfAccountTimeRange{}, err)
+}
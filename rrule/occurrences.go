@@ -0,0 +1,131 @@
+package rrule
+
+import (
+	"time"
+
+	"github.com/glynternet/go-accounting/schedule"
+)
+
+var _ schedule.Recurrence = Rule{}
+
+// Occurrences returns the times of the occurrences of the Rule that are
+// after the after time and at or before the until time, in order.
+// Occurrences before the Start of the Rule are never returned. Excluded times
+// are not returned but are still counted towards the COUNT of the Rule.
+// Occurrences ensures that Rule adheres to the schedule.Recurrence interface.
+func (r Rule) Occurrences(after, until time.Time) []time.Time {
+	if !r.Until.IsZero() && until.After(r.Until) {
+		until = r.Until
+	}
+	var ts []time.Time
+	var count int
+	for k := 0; ; k++ {
+		start, end := r.period(k)
+		if start.After(until) {
+			return ts
+		}
+		for d := start; d.Before(end); d = d.AddDate(0, 0, 1) {
+			if !r.matches(d) {
+				continue
+			}
+			t := time.Date(d.Year(), d.Month(), d.Day(), r.Start.Hour(), r.Start.Minute(), r.Start.Second(), r.Start.Nanosecond(), r.Start.Location())
+			if t.Before(r.Start) {
+				continue
+			}
+			if t.After(until) {
+				return ts
+			}
+			count++
+			if r.Count > 0 && count > r.Count {
+				return ts
+			}
+			if t.After(after) && !r.excluded(t) {
+				ts = append(ts, t)
+			}
+		}
+	}
+}
+
+// period returns the first day of the kth period of the Rule and the first
+// day after it.
+func (r Rule) period(k int) (time.Time, time.Time) {
+	y, m, d := r.Start.Date()
+	loc := r.Start.Location()
+	n := k * r.Interval
+	switch r.Frequency {
+	case Weekly:
+		monday := time.Date(y, m, d-(int(r.Start.Weekday())+6)%7, 0, 0, 0, 0, loc)
+		start := monday.AddDate(0, 0, 7*n)
+		return start, start.AddDate(0, 0, 7)
+	case Monthly:
+		start := time.Date(y, m+time.Month(n), 1, 0, 0, 0, 0, loc)
+		return start, start.AddDate(0, 1, 0)
+	case Yearly:
+		start := time.Date(y+n, time.January, 1, 0, 0, 0, 0, loc)
+		return start, start.AddDate(1, 0, 0)
+	}
+	start := time.Date(y, m, d+n, 0, 0, 0, 0, loc)
+	return start, start.AddDate(0, 0, 1)
+}
+
+// matches returns true if the given day is one on which the Rule occurs
+// within its period.
+func (r Rule) matches(d time.Time) bool {
+	if len(r.ByDay) == 0 && len(r.ByMonthDay) == 0 {
+		switch r.Frequency {
+		case Weekly:
+			return d.Weekday() == r.Start.Weekday()
+		case Monthly:
+			return d.Day() == r.Start.Day()
+		case Yearly:
+			return d.Month() == r.Start.Month() && d.Day() == r.Start.Day()
+		}
+		return true
+	}
+	if len(r.ByMonthDay) > 0 && !r.matchesMonthDay(d) {
+		return false
+	}
+	return len(r.ByDay) == 0 || r.matchesWeekday(d)
+}
+
+func (r Rule) matchesMonthDay(d time.Time) bool {
+	days := daysIn(d.Year(), d.Month())
+	for _, n := range r.ByMonthDay {
+		if n == d.Day() || n < 0 && days+n+1 == d.Day() {
+			return true
+		}
+	}
+	return false
+}
+
+func (r Rule) matchesWeekday(d time.Time) bool {
+	day, days := d.Day(), daysIn(d.Year(), d.Month())
+	if r.Frequency == Yearly {
+		day, days = d.YearDay(), time.Date(d.Year(), time.December, 31, 0, 0, 0, 0, time.UTC).YearDay()
+	}
+	for _, wd := range r.ByDay {
+		if wd.Weekday != d.Weekday() {
+			continue
+		}
+		switch {
+		case wd.N == 0,
+			wd.N > 0 && (day-1)/7+1 == wd.N,
+			wd.N < 0 && -((days-day)/7+1) == wd.N:
+			return true
+		}
+	}
+	return false
+}
+
+func (r Rule) excluded(t time.Time) bool {
+	for _, ex := range r.ExDates {
+		if ex.Equal(t) {
+			return true
+		}
+	}
+	return false
+}
+
+func daysIn(year int, month time.Month) int {
+	return time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
@@ -0,0 +1,317 @@
+// Package rrule parses the recurrence rules of RFC 5545 iCalendar events and
+// generates their occurrences as a schedule.Recurrence.
+//
+// The FREQ values DAILY, WEEKLY, MONTHLY and YEARLY are supported, along with
+// the INTERVAL, BYDAY, BYMONTHDAY, COUNT and UNTIL rule parts and EXDATE
+// exclusions. Other rule parts are rejected rather than ignored, so that a
+// rule is never silently given a different meaning. Weeks start on Monday,
+// the default WKST of RFC 5545.
+package rrule
+
+import (
+	"bufio"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Various error messages describing possible errors when parsing a Rule.
+const (
+	ErrMissingStart         = "missing DTSTART"
+	ErrMissingRule          = "missing RRULE"
+	ErrMissingFrequency     = "missing FREQ"
+	ErrUnsupportedFrequency = "unsupported FREQ"
+	ErrUnsupportedPart      = "unsupported rule part"
+	ErrInvalidPart          = "invalid rule part"
+	ErrInvalidInterval      = "INTERVAL must be greater than zero"
+	ErrInvalidCount         = "COUNT must be greater than zero"
+	ErrCountAndUntil        = "COUNT and UNTIL must not both be given"
+	ErrInvalidByDay         = "invalid BYDAY"
+	ErrInvalidByMonthDay    = "invalid BYMONTHDAY"
+	ErrInvalidDateTime      = "invalid date time"
+)
+
+// Frequency is the period over which a Rule repeats.
+type Frequency int
+
+// The various Frequencies that a Rule can have.
+const (
+	Daily Frequency = iota + 1
+	Weekly
+	Monthly
+	Yearly
+)
+
+var frequencies = map[string]Frequency{
+	"DAILY":   Daily,
+	"WEEKLY":  Weekly,
+	"MONTHLY": Monthly,
+	"YEARLY":  Yearly,
+}
+
+var weekdays = map[string]time.Weekday{
+	"SU": time.Sunday,
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+}
+
+// Weekday is a day of the week given in a BYDAY rule part, such as MO or
+// -1FR. A zero N matches every such day in the period of the Rule, otherwise
+// N gives the Nth such day of the month or year, counting from the end when
+// negative.
+type Weekday struct {
+	N       int
+	Weekday time.Weekday
+}
+
+// Rule is a parsed recurrence rule, with the start time of the first
+// occurrence and any excluded times.
+type Rule struct {
+	Start      time.Time
+	Frequency  Frequency
+	Interval   int
+	ByDay      []Weekday
+	ByMonthDay []int
+	Count      int
+	Until      time.Time
+	ExDates    []time.Time
+}
+
+// Parse parses the DTSTART, RRULE and EXDATE properties of an iCalendar
+// event into a Rule. Other properties are ignored, so the lines of a whole
+// VEVENT can be given. Folded lines are unfolded before parsing.
+// A DTSTART and RRULE are required and EXDATE can be given any number of
+// times.
+func Parse(s string) (*Rule, error) {
+	var start, rule string
+	var exdates []string
+	for _, line := range unfold(s) {
+		name, value := splitProperty(line)
+		switch strings.ToUpper(strings.SplitN(name, ";", 2)[0]) {
+		case "DTSTART":
+			start = line
+		case "RRULE":
+			rule = value
+		case "EXDATE":
+			exdates = append(exdates, line)
+		}
+	}
+	if start == "" {
+		return nil, errors.New(ErrMissingStart)
+	}
+	if rule == "" {
+		return nil, errors.New(ErrMissingRule)
+	}
+	starts, err := parseDateTimes(start, time.UTC)
+	if err != nil {
+		return nil, errors.Wrap(err, "parsing DTSTART")
+	}
+	if len(starts) != 1 {
+		return nil, errors.Wrap(errors.New(ErrInvalidDateTime), "parsing DTSTART")
+	}
+	var ex []time.Time
+	for _, line := range exdates {
+		ts, err := parseDateTimes(line, starts[0].Location())
+		if err != nil {
+			return nil, errors.Wrap(err, "parsing EXDATE")
+		}
+		ex = append(ex, ts...)
+	}
+	return ParseRule(starts[0], rule, ex...)
+}
+
+// ParseRule parses the value of an RRULE property, such as
+// FREQ=MONTHLY;BYDAY=-1FR;COUNT=12, into a Rule that starts at the given
+// time and excludes any of the given times.
+// Times within the rule, such as UNTIL, without a time zone are taken to be
+// in the location of the start time.
+func ParseRule(start time.Time, rule string, exdates ...time.Time) (*Rule, error) {
+	r := &Rule{
+		Start:    start,
+		Interval: 1,
+		ExDates:  append([]time.Time(nil), exdates...),
+	}
+	rule = strings.TrimPrefix(strings.TrimSpace(rule), "RRULE:")
+	for _, part := range strings.Split(rule, ";") {
+		if part == "" {
+			continue
+		}
+		kv := strings.SplitN(part, "=", 2)
+		if len(kv) != 2 {
+			return nil, errors.Wrapf(errors.New(ErrInvalidPart), "%q", part)
+		}
+		if err := r.setPart(strings.ToUpper(kv[0]), strings.ToUpper(kv[1])); err != nil {
+			return nil, errors.Wrapf(err, "parsing %s", kv[0])
+		}
+	}
+	if r.Frequency == 0 {
+		return nil, errors.New(ErrMissingFrequency)
+	}
+	if r.Count > 0 && !r.Until.IsZero() {
+		return nil, errors.New(ErrCountAndUntil)
+	}
+	if r.Frequency == Daily || r.Frequency == Weekly {
+		for _, wd := range r.ByDay {
+			if wd.N != 0 {
+				return nil, errors.Wrap(errors.New(ErrInvalidByDay), "numbered BYDAY is only valid with MONTHLY or YEARLY")
+			}
+		}
+	}
+	if r.Frequency == Weekly && len(r.ByMonthDay) > 0 {
+		return nil, errors.Wrap(errors.New(ErrInvalidByMonthDay), "BYMONTHDAY is not valid with WEEKLY")
+	}
+	return r, nil
+}
+
+func (r *Rule) setPart(name, value string) error {
+	switch name {
+	case "FREQ":
+		f, ok := frequencies[value]
+		if !ok {
+			return errors.Wrapf(errors.New(ErrUnsupportedFrequency), "%q", value)
+		}
+		r.Frequency = f
+	case "INTERVAL":
+		n, err := strconv.Atoi(value)
+		if err != nil || n <= 0 {
+			return errors.New(ErrInvalidInterval)
+		}
+		r.Interval = n
+	case "COUNT":
+		n, err := strconv.Atoi(value)
+		if err != nil || n <= 0 {
+			return errors.New(ErrInvalidCount)
+		}
+		r.Count = n
+	case "UNTIL":
+		t, err := parseDateTime(value, r.Start.Location())
+		if err != nil {
+			return err
+		}
+		if len(value) == len(dateLayout) {
+			t = t.AddDate(0, 0, 1).Add(-time.Nanosecond)
+		}
+		r.Until = t
+	case "BYDAY":
+		for _, v := range strings.Split(value, ",") {
+			wd, err := parseWeekday(v)
+			if err != nil {
+				return err
+			}
+			r.ByDay = append(r.ByDay, wd)
+		}
+	case "BYMONTHDAY":
+		for _, v := range strings.Split(value, ",") {
+			n, err := strconv.Atoi(v)
+			if err != nil || n == 0 || n < -31 || n > 31 {
+				return errors.Wrapf(errors.New(ErrInvalidByMonthDay), "%q", v)
+			}
+			r.ByMonthDay = append(r.ByMonthDay, n)
+		}
+	default:
+		return errors.New(ErrUnsupportedPart)
+	}
+	return nil
+}
+
+func parseWeekday(s string) (Weekday, error) {
+	if len(s) < 2 {
+		return Weekday{}, errors.Wrapf(errors.New(ErrInvalidByDay), "%q", s)
+	}
+	wd, ok := weekdays[s[len(s)-2:]]
+	if !ok {
+		return Weekday{}, errors.Wrapf(errors.New(ErrInvalidByDay), "%q", s)
+	}
+	var n int
+	if prefix := s[:len(s)-2]; prefix != "" {
+		var err error
+		n, err = strconv.Atoi(prefix)
+		if err != nil || n == 0 || n < -53 || n > 53 {
+			return Weekday{}, errors.Wrapf(errors.New(ErrInvalidByDay), "%q", s)
+		}
+	}
+	return Weekday{N: n, Weekday: wd}, nil
+}
+
+const (
+	dateLayout     = "20060102"
+	dateTimeLayout = "20060102T150405"
+)
+
+// parseDateTimes parses the comma separated date or date time values of a
+// DTSTART or EXDATE property line, using the location given by any TZID
+// parameter or else the given location.
+func parseDateTimes(line string, loc *time.Location) ([]time.Time, error) {
+	name, value := splitProperty(line)
+	for _, param := range strings.Split(name, ";")[1:] {
+		kv := strings.SplitN(param, "=", 2)
+		if len(kv) == 2 && strings.ToUpper(kv[0]) == "TZID" {
+			l, err := time.LoadLocation(strings.Trim(kv[1], `"`))
+			if err != nil {
+				return nil, errors.Wrapf(err, "loading TZID %q", kv[1])
+			}
+			loc = l
+		}
+	}
+	var ts []time.Time
+	for _, v := range strings.Split(value, ",") {
+		t, err := parseDateTime(v, loc)
+		if err != nil {
+			return nil, err
+		}
+		ts = append(ts, t)
+	}
+	return ts, nil
+}
+
+// parseDateTime parses a date, such as 20200131, or a date time, such as
+// 20200131T090000, in the given location, or in UTC if it ends with Z.
+func parseDateTime(s string, loc *time.Location) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	layout := dateTimeLayout
+	switch {
+	case len(s) == len(dateLayout):
+		layout = dateLayout
+	case strings.HasSuffix(s, "Z"):
+		s, loc = strings.TrimSuffix(s, "Z"), time.UTC
+	}
+	t, err := time.ParseInLocation(layout, s, loc)
+	if err != nil {
+		return time.Time{}, errors.Wrapf(errors.New(ErrInvalidDateTime), "%q", s)
+	}
+	return t, nil
+}
+
+// splitProperty splits a content line into its name, including any
+// parameters, and its value.
+func splitProperty(line string) (string, string) {
+	i := strings.IndexByte(line, ':')
+	if i < 0 {
+		return line, ""
+	}
+	return line[:i], line[i+1:]
+}
+
+// unfold returns the content lines of s, joining any line that begins with
+// a space or tab onto the line before it.
+func unfold(s string) []string {
+	var lines []string
+	scanner := bufio.NewScanner(strings.NewReader(s))
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if len(lines) > 0 && (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		if line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
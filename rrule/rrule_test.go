@@ -0,0 +1,147 @@
+package rrule_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/glynternet/go-accounting/accountingtest"
+	"github.com/glynternet/go-accounting/rrule"
+	"github.com/stretchr/testify/assert"
+)
+
+// nineAM returns a time at 09:00 UTC on the given day, so that tests show
+// that the time of day of a start is kept.
+func nineAM(year int, month time.Month, day int) time.Time {
+	return accountingtest.Date(year, month, day).Add(9 * time.Hour)
+}
+
+func TestParse(t *testing.T) {
+	r, err := rrule.Parse("BEGIN:VEVENT\r\n" +
+		"SUMMARY:Rent\r\n" +
+		"DTSTART;TZID=UTC:20200101T090000\r\n" +
+		"RRULE:FREQ=MONTHLY;INTERVAL=2;BYDAY=MO,-1FR;\r\n" +
+		" BYMONTHDAY=1,-1;UNTIL=20201231T000000Z\r\n" +
+		"EXDATE:20200301T090000Z,20200501T090000Z\r\n" +
+		"EXDATE;VALUE=DATE-TIME:20200701T090000\r\n" +
+		"END:VEVENT\r\n")
+	assert.Nil(t, err)
+	assert.Equal(t, &rrule.Rule{
+		Start:     nineAM(2020, 1, 1),
+		Frequency: rrule.Monthly,
+		Interval:  2,
+		ByDay: []rrule.Weekday{
+			{Weekday: time.Monday},
+			{N: -1, Weekday: time.Friday},
+		},
+		ByMonthDay: []int{1, -1},
+		Until:      time.Date(2020, 12, 31, 0, 0, 0, 0, time.UTC),
+		ExDates:    []time.Time{nineAM(2020, 3, 1), nineAM(2020, 5, 1), nineAM(2020, 7, 1)},
+	}, r)
+}
+
+func TestParse_Errors(t *testing.T) {
+	for _, test := range []struct {
+		name, ical, err string
+	}{
+		{name: "no start", ical: "RRULE:FREQ=DAILY", err: rrule.ErrMissingStart},
+		{name: "no rule", ical: "DTSTART:20200101", err: rrule.ErrMissingRule},
+		{name: "invalid start", ical: "DTSTART:2020-01-01\nRRULE:FREQ=DAILY", err: `parsing DTSTART: "2020-01-01": ` + rrule.ErrInvalidDateTime},
+		{name: "invalid exdate", ical: "DTSTART:20200101\nRRULE:FREQ=DAILY\nEXDATE:x", err: `parsing EXDATE: "x": ` + rrule.ErrInvalidDateTime},
+		{name: "no frequency", ical: "DTSTART:20200101\nRRULE:COUNT=1", err: rrule.ErrMissingFrequency},
+		{name: "unsupported frequency", ical: "DTSTART:20200101\nRRULE:FREQ=HOURLY", err: `parsing FREQ: "HOURLY": ` + rrule.ErrUnsupportedFrequency},
+		{name: "unsupported part", ical: "DTSTART:20200101\nRRULE:FREQ=DAILY;BYMONTH=1", err: "parsing BYMONTH: " + rrule.ErrUnsupportedPart},
+		{name: "invalid part", ical: "DTSTART:20200101\nRRULE:FREQ=DAILY;COUNT", err: `"COUNT": ` + rrule.ErrInvalidPart},
+		{name: "interval", ical: "DTSTART:20200101\nRRULE:FREQ=DAILY;INTERVAL=0", err: "parsing INTERVAL: " + rrule.ErrInvalidInterval},
+		{name: "count", ical: "DTSTART:20200101\nRRULE:FREQ=DAILY;COUNT=x", err: "parsing COUNT: " + rrule.ErrInvalidCount},
+		{name: "count and until", ical: "DTSTART:20200101\nRRULE:FREQ=DAILY;COUNT=1;UNTIL=20200102", err: rrule.ErrCountAndUntil},
+		{name: "byday", ical: "DTSTART:20200101\nRRULE:FREQ=MONTHLY;BYDAY=XX", err: `parsing BYDAY: "XX": ` + rrule.ErrInvalidByDay},
+		{name: "numbered weekly byday", ical: "DTSTART:20200101\nRRULE:FREQ=WEEKLY;BYDAY=1MO", err: "numbered BYDAY is only valid with MONTHLY or YEARLY: " + rrule.ErrInvalidByDay},
+		{name: "bymonthday", ical: "DTSTART:20200101\nRRULE:FREQ=MONTHLY;BYMONTHDAY=32", err: `parsing BYMONTHDAY: "32": ` + rrule.ErrInvalidByMonthDay},
+		{name: "weekly bymonthday", ical: "DTSTART:20200101\nRRULE:FREQ=WEEKLY;BYMONTHDAY=1", err: "BYMONTHDAY is not valid with WEEKLY: " + rrule.ErrInvalidByMonthDay},
+	} {
+		_, err := rrule.Parse(test.ical)
+		assert.EqualError(t, err, test.err, test.name)
+	}
+}
+
+func TestRule_Occurrences(t *testing.T) {
+	for _, test := range []struct {
+		name, rule   string
+		start        time.Time
+		exdates      []time.Time
+		after, until time.Time
+		expected     []time.Time
+	}{
+		{
+			name:  "daily weekdays",
+			rule:  "FREQ=DAILY;BYDAY=MO,TU,WE,TH,FR",
+			start: nineAM(2020, 1, 3), after: nineAM(2020, 1, 1), until: nineAM(2020, 1, 8),
+			expected: []time.Time{nineAM(2020, 1, 3), nineAM(2020, 1, 6), nineAM(2020, 1, 7), nineAM(2020, 1, 8)},
+		},
+		{
+			name:  "count includes excluded and earlier occurrences",
+			rule:  "FREQ=DAILY;COUNT=4",
+			start: nineAM(2020, 1, 1), exdates: []time.Time{nineAM(2020, 1, 3)},
+			after: nineAM(2020, 1, 1), until: nineAM(2021, 1, 1),
+			expected: []time.Time{nineAM(2020, 1, 2), nineAM(2020, 1, 4)},
+		},
+		{
+			name:  "fortnightly on two days",
+			rule:  "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,WE;UNTIL=20200129",
+			start: nineAM(2020, 1, 1), after: nineAM(2019, 1, 1), until: nineAM(2021, 1, 1),
+			expected: []time.Time{nineAM(2020, 1, 1), nineAM(2020, 1, 13), nineAM(2020, 1, 15), nineAM(2020, 1, 27), nineAM(2020, 1, 29)},
+		},
+		{
+			name:  "weekly default weekday",
+			rule:  "FREQ=WEEKLY",
+			start: nineAM(2020, 1, 2), after: nineAM(2019, 1, 1), until: nineAM(2020, 1, 16),
+			expected: []time.Time{nineAM(2020, 1, 2), nineAM(2020, 1, 9), nineAM(2020, 1, 16)},
+		},
+		{
+			name:  "monthly default day skips short months",
+			rule:  "FREQ=MONTHLY",
+			start: nineAM(2020, 1, 31), after: nineAM(2019, 1, 1), until: nineAM(2020, 5, 31),
+			expected: []time.Time{nineAM(2020, 1, 31), nineAM(2020, 3, 31), nineAM(2020, 5, 31)},
+		},
+		{
+			name:  "last day of month",
+			rule:  "FREQ=MONTHLY;BYMONTHDAY=-1",
+			start: nineAM(2020, 1, 1), after: nineAM(2019, 1, 1), until: nineAM(2020, 3, 31),
+			expected: []time.Time{nineAM(2020, 1, 31), nineAM(2020, 2, 29), nineAM(2020, 3, 31)},
+		},
+		{
+			name:  "last friday",
+			rule:  "FREQ=MONTHLY;BYDAY=-1FR;COUNT=3",
+			start: nineAM(2020, 1, 1), after: nineAM(2019, 1, 1), until: nineAM(2021, 1, 1),
+			expected: []time.Time{nineAM(2020, 1, 31), nineAM(2020, 2, 28), nineAM(2020, 3, 27)},
+		},
+		{
+			name:  "friday 13th",
+			rule:  "FREQ=MONTHLY;BYDAY=FR;BYMONTHDAY=13",
+			start: nineAM(2020, 1, 1), after: nineAM(2019, 1, 1), until: nineAM(2020, 12, 31),
+			expected: []time.Time{nineAM(2020, 3, 13), nineAM(2020, 11, 13)},
+		},
+		{
+			name:  "yearly default day",
+			rule:  "FREQ=YEARLY;INTERVAL=2",
+			start: nineAM(2020, 6, 15), after: nineAM(2019, 1, 1), until: nineAM(2024, 12, 31),
+			expected: []time.Time{nineAM(2020, 6, 15), nineAM(2022, 6, 15), nineAM(2024, 6, 15)},
+		},
+		{
+			name:  "first monday of the year",
+			rule:  "FREQ=YEARLY;BYDAY=1MO",
+			start: nineAM(2020, 1, 1), after: nineAM(2019, 1, 1), until: nineAM(2021, 12, 31),
+			expected: []time.Time{nineAM(2020, 1, 6), nineAM(2021, 1, 4)},
+		},
+		{
+			name:  "until date is inclusive",
+			rule:  "FREQ=DAILY;UNTIL=20200102",
+			start: nineAM(2020, 1, 1), after: nineAM(2019, 1, 1), until: nineAM(2021, 1, 1),
+			expected: []time.Time{nineAM(2020, 1, 1), nineAM(2020, 1, 2)},
+		},
+	} {
+		r, err := rrule.ParseRule(test.start, test.rule, test.exdates...)
+		assert.Nil(t, err, test.name)
+		assert.Equal(t, test.expected, r.Occurrences(test.after, test.until), test.name)
+	}
+}
@@ -0,0 +1,46 @@
+package rules
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/pkg/errors"
+)
+
+// FileVersion is the version of the rule file json schema that is produced by Write.
+const FileVersion = 1
+
+// ErrUnsupportedFileVersion is the error message used when reading a rule file with an unknown version.
+const ErrUnsupportedFileVersion = "unsupported rule file version"
+
+// file is the json schema of a rule file.
+//
+// A rule file is an object with the following fields:
+//
+//	Version number, the FileVersion of the schema
+//	Rules   array, the Rules, each as an object with the fields of Rule
+type file struct {
+	Version int
+	Rules   []Rule
+}
+
+// Read reads a json rule file, as written by Write, and creates an Engine
+// from its Rules.
+func Read(r io.Reader) (*Engine, error) {
+	var f file
+	if err := json.NewDecoder(r).Decode(&f); err != nil {
+		return nil, errors.Wrap(err, "decoding rule file")
+	}
+	if f.Version != FileVersion {
+		return nil, errors.Wrapf(errors.New(ErrUnsupportedFileVersion), "version %d", f.Version)
+	}
+	return New(f.Rules...)
+}
+
+// Write writes the Rules of an Engine as a json rule file, in the order that
+// they are tried.
+func Write(w io.Writer, e Engine) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "\t")
+	return errors.Wrap(enc.Encode(file{Version: FileVersion, Rules: e.Rules()}), "encoding rule file")
+}
@@ -0,0 +1,50 @@
+package rules_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/glynternet/go-accounting/rules"
+	"github.com/stretchr/testify/assert"
+)
+
+const ruleFile = `{
+	"Version": 1,
+	"Rules": [
+		{"Name": "salary", "Contains": "ACME", "MinAmount": 0, "Category": "income", "Payee": "ACME Ltd"},
+		{"Name": "rent", "Priority": 1, "Pattern": "^SO RENT", "Account": "Current", "Category": "housing"}
+	]
+}`
+
+func TestRead(t *testing.T) {
+	e, err := rules.Read(strings.NewReader(ruleFile))
+	assert.Nil(t, err)
+	assert.Equal(t, []rules.Rule{
+		{Name: "rent", Priority: 1, Pattern: "^SO RENT", Account: "Current", Category: "housing"},
+		{Name: "salary", Contains: "ACME", MinAmount: amount(0), Category: "income", Payee: "ACME Ltd"},
+	}, e.Rules())
+}
+
+func TestRead_Errors(t *testing.T) {
+	for _, test := range []struct {
+		name, file, err string
+	}{
+		{name: "invalid json", file: "{", err: "decoding rule file: unexpected EOF"},
+		{name: "version", file: `{"Version": 2}`, err: "version 2: " + rules.ErrUnsupportedFileVersion},
+		{name: "invalid rule", file: `{"Version": 1, "Rules": [{"Name": "r"}]}`, err: `rule "r": ` + rules.ErrNoConditions},
+	} {
+		_, err := rules.Read(strings.NewReader(test.file))
+		assert.EqualError(t, err, test.err, test.name)
+	}
+}
+
+func TestWrite(t *testing.T) {
+	e, err := rules.Read(strings.NewReader(ruleFile))
+	assert.Nil(t, err)
+	var buf bytes.Buffer
+	assert.Nil(t, rules.Write(&buf, *e))
+	read, err := rules.Read(&buf)
+	assert.Nil(t, err)
+	assert.Equal(t, e.Rules(), read.Rules())
+}
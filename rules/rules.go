@@ -0,0 +1,185 @@
+// Package rules assigns categories and payees to Transactions using Rules
+// that match their descriptions, amounts and Accounts.
+package rules
+
+import (
+	"errors"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/glynternet/go-accounting/transaction"
+	pkgerrors "github.com/pkg/errors"
+)
+
+// Various error messages describing possible errors when creating an Engine.
+const (
+	ErrEmptyName          = "empty rule name"
+	ErrDuplicateName      = "duplicate rule name"
+	ErrNoConditions       = "rule has no conditions"
+	ErrNoAssignments      = "rule assigns neither a category nor a payee"
+	ErrInvalidAmountRange = "rule minimum amount is greater than its maximum amount"
+)
+
+// Rule assigns a Category and Payee to the Transactions that it matches.
+// A Rule matches a Transaction when every one of its conditions that is set
+// is met:
+//
+//	Contains  the Description contains the text, ignoring case
+//	Pattern   the Description matches the regular expression
+//	MinAmount the Amount is at least the value
+//	MaxAmount the Amount is at most the value
+//	Account   the Transaction has an Account with exactly the name
+//
+// Rules with a higher Priority are tried first.
+type Rule struct {
+	Name      string
+	Priority  int
+	Contains  string `json:",omitempty"`
+	Pattern   string `json:",omitempty"`
+	MinAmount *int   `json:",omitempty"`
+	MaxAmount *int   `json:",omitempty"`
+	Account   string `json:",omitempty"`
+	Category  string `json:",omitempty"`
+	Payee     string `json:",omitempty"`
+}
+
+// New creates a new Engine from the given Rules.
+// New returns an error if any Rule has no name, no conditions or nothing to
+// assign, has an invalid Pattern or amount range, or shares its name with
+// another Rule.
+func New(rs ...Rule) (*Engine, error) {
+	e := &Engine{}
+	names := make(map[string]bool)
+	for _, r := range rs {
+		c, err := compile(r)
+		if err != nil {
+			return nil, pkgerrors.Wrapf(err, "rule %q", r.Name)
+		}
+		if names[r.Name] {
+			return nil, pkgerrors.Wrapf(errors.New(ErrDuplicateName), "rule %q", r.Name)
+		}
+		names[r.Name] = true
+		e.rules = append(e.rules, c)
+	}
+	sort.SliceStable(e.rules, func(i, j int) bool {
+		return e.rules[i].Priority > e.rules[j].Priority
+	})
+	return e, nil
+}
+
+func compile(r Rule) (compiled, error) {
+	switch {
+	case r.Name == "":
+		return compiled{}, errors.New(ErrEmptyName)
+	case r.Contains == "" && r.Pattern == "" && r.MinAmount == nil && r.MaxAmount == nil && r.Account == "":
+		return compiled{}, errors.New(ErrNoConditions)
+	case r.Category == "" && r.Payee == "":
+		return compiled{}, errors.New(ErrNoAssignments)
+	case r.MinAmount != nil && r.MaxAmount != nil && *r.MinAmount > *r.MaxAmount:
+		return compiled{}, errors.New(ErrInvalidAmountRange)
+	}
+	c := compiled{Rule: r}
+	if r.Pattern != "" {
+		var err error
+		if c.pattern, err = regexp.Compile(r.Pattern); err != nil {
+			return compiled{}, pkgerrors.Wrap(err, "compiling pattern")
+		}
+	}
+	return c, nil
+}
+
+type compiled struct {
+	Rule
+	pattern *regexp.Regexp
+}
+
+func (c compiled) matches(t transaction.Transaction) bool {
+	switch {
+	case c.Contains != "" && !strings.Contains(strings.ToLower(t.Description), strings.ToLower(c.Contains)):
+		return false
+	case c.pattern != nil && !c.pattern.MatchString(t.Description):
+		return false
+	case c.MinAmount != nil && t.Amount < *c.MinAmount:
+		return false
+	case c.MaxAmount != nil && t.Amount > *c.MaxAmount:
+		return false
+	case c.Account != "" && (t.Account == nil || t.Account.Name() != c.Account):
+		return false
+	}
+	return true
+}
+
+// Engine holds Rules in the order that they are tried.
+type Engine struct {
+	rules []compiled
+}
+
+// Rules returns the Rules of the Engine in the order that they are tried:
+// by descending Priority and then in the order that they were given.
+func (e Engine) Rules() []Rule {
+	rs := make([]Rule, len(e.rules))
+	for i, c := range e.rules {
+		rs[i] = c.Rule
+	}
+	return rs
+}
+
+// Match returns the first Rule that matches the Transaction.
+// The returned bool is false if no Rule matches.
+func (e Engine) Match(t transaction.Transaction) (Rule, bool) {
+	for _, c := range e.rules {
+		if c.matches(t) {
+			return c.Rule, true
+		}
+	}
+	return Rule{}, false
+}
+
+// Result describes the outcome of applying an Engine to a Transaction.
+// Rule is the name of the Rule that matched and is empty if none matched.
+// Transaction is the Transaction with the Category and Payee of the Rule
+// applied.
+type Result struct {
+	Original    transaction.Transaction
+	Transaction transaction.Transaction
+	Rule        string
+}
+
+// Changed returns true if applying the Rule changed the Transaction.
+func (r Result) Changed() bool {
+	return !r.Original.Equal(r.Transaction)
+}
+
+// DryRun returns the Result of applying the Engine to each of the
+// Transactions, without altering them.
+// Only the Category and Payee fields that are empty are set, so that
+// Transactions that have already been tagged by hand keep their tags.
+func (e Engine) DryRun(ts transaction.Transactions) []Result {
+	rs := make([]Result, len(ts))
+	for i, t := range ts {
+		rs[i] = Result{Original: t, Transaction: t}
+		r, ok := e.Match(t)
+		if !ok {
+			continue
+		}
+		rs[i].Rule = r.Name
+		if rs[i].Transaction.Category == "" {
+			rs[i].Transaction.Category = r.Category
+		}
+		if rs[i].Transaction.Payee == "" {
+			rs[i].Transaction.Payee = r.Payee
+		}
+	}
+	return rs
+}
+
+// Apply returns a copy of the Transactions with the Engine applied to them,
+// as described by DryRun.
+func (e Engine) Apply(ts transaction.Transactions) transaction.Transactions {
+	applied := make(transaction.Transactions, len(ts))
+	for i, r := range e.DryRun(ts) {
+		applied[i] = r.Transaction
+	}
+	return applied
+}
@@ -0,0 +1,109 @@
+package rules_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/glynternet/go-accounting/accountingtest"
+	"github.com/glynternet/go-accounting/rules"
+	"github.com/glynternet/go-accounting/transaction"
+	"github.com/stretchr/testify/assert"
+)
+
+func amount(a int) *int {
+	return &a
+}
+
+func TestNew_Errors(t *testing.T) {
+	for _, test := range []struct {
+		name string
+		rs   []rules.Rule
+		err  string
+	}{
+		{name: "no name", rs: []rules.Rule{{Contains: "a", Category: "c"}}, err: `rule "": ` + rules.ErrEmptyName},
+		{name: "no conditions", rs: []rules.Rule{{Name: "r", Category: "c"}}, err: `rule "r": ` + rules.ErrNoConditions},
+		{name: "no assignments", rs: []rules.Rule{{Name: "r", Contains: "a"}}, err: `rule "r": ` + rules.ErrNoAssignments},
+		{name: "amount range", rs: []rules.Rule{{Name: "r", MinAmount: amount(1), MaxAmount: amount(0), Payee: "p"}}, err: `rule "r": ` + rules.ErrInvalidAmountRange},
+		{name: "pattern", rs: []rules.Rule{{Name: "r", Pattern: "(", Payee: "p"}}, err: "rule \"r\": compiling pattern: error parsing regexp: missing closing ): `(`"},
+		{
+			name: "duplicate",
+			rs:   []rules.Rule{{Name: "r", Contains: "a", Payee: "p"}, {Name: "r", Contains: "b", Payee: "p"}},
+			err:  `rule "r": ` + rules.ErrDuplicateName,
+		},
+	} {
+		_, err := rules.New(test.rs...)
+		assert.EqualError(t, err, test.err, test.name)
+	}
+}
+
+func TestEngine_Match(t *testing.T) {
+	current := *accountingtest.NewAccount(t, "Current", accountingtest.NewCurrencyCode(t, "GBP"), time.Now())
+	e, err := rules.New(
+		rules.Rule{Name: "shopping", Contains: "tesco", Category: "shopping"},
+		rules.Rule{Name: "fuel", Priority: 10, Pattern: `^TESCO PFS \d+`, Category: "fuel", Payee: "Tesco"},
+		rules.Rule{Name: "big spend", Priority: 5, MaxAmount: amount(-10000), Category: "review"},
+		rules.Rule{Name: "interest", MinAmount: amount(0), MaxAmount: amount(100), Account: "Current", Category: "interest"},
+	)
+	assert.Nil(t, err)
+	var names []string
+	for _, r := range e.Rules() {
+		names = append(names, r.Name)
+	}
+	assert.Equal(t, []string{"fuel", "big spend", "shopping", "interest"}, names)
+
+	for _, test := range []struct {
+		transaction.Transaction
+		rule string
+	}{
+		{Transaction: transaction.Transaction{Description: "TESCO PFS 1234", Amount: -20000}, rule: "fuel"},
+		{Transaction: transaction.Transaction{Description: "Tesco Stores", Amount: -20000}, rule: "big spend"},
+		{Transaction: transaction.Transaction{Description: "tesco stores", Amount: -500}, rule: "shopping"},
+		{Transaction: transaction.Transaction{Description: "INTEREST", Amount: 100, Account: &current}, rule: "interest"},
+		{Transaction: transaction.Transaction{Description: "INTEREST", Amount: 101, Account: &current}},
+		{Transaction: transaction.Transaction{Description: "INTEREST", Amount: 50}},
+	} {
+		r, ok := e.Match(test.Transaction)
+		assert.Equal(t, test.rule != "", ok, test.Description)
+		assert.Equal(t, test.rule, r.Name, test.Description)
+	}
+}
+
+func TestEngine_DryRun(t *testing.T) {
+	e, err := rules.New(rules.Rule{Name: "fuel", Contains: "pfs", Category: "fuel", Payee: "Tesco"})
+	assert.Nil(t, err)
+	ts := transaction.Transactions{
+		{Description: "TESCO PFS"},
+		{Description: "TESCO PFS", Category: "car"},
+		{Description: "RENT"},
+	}
+
+	rs := e.DryRun(ts)
+	assert.Equal(t, []rules.Result{
+		{
+			Original:    ts[0],
+			Transaction: transaction.Transaction{Description: "TESCO PFS", Category: "fuel", Payee: "Tesco"},
+			Rule:        "fuel",
+		},
+		{
+			Original:    ts[1],
+			Transaction: transaction.Transaction{Description: "TESCO PFS", Category: "car", Payee: "Tesco"},
+			Rule:        "fuel",
+		},
+		{Original: ts[2], Transaction: ts[2]},
+	}, rs)
+	assert.True(t, rs[0].Changed())
+	assert.False(t, rs[2].Changed())
+	assert.Empty(t, ts[0].Category, "DryRun should not alter the given Transactions")
+
+	applied := e.Apply(ts)
+	assert.Equal(t, rs[0].Transaction, applied[0])
+	assert.Equal(t, rs[1].Transaction, applied[1])
+	assert.Equal(t, ts[2], applied[2])
+}
+
+func TestEngine_Empty(t *testing.T) {
+	e, err := rules.New()
+	assert.Nil(t, err)
+	_, ok := e.Match(transaction.Transaction{})
+	assert.False(t, ok)
+}
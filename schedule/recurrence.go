@@ -0,0 +1,156 @@
+package schedule
+
+import (
+	"errors"
+	"time"
+
+	"github.com/glynternet/go-accounting/balance"
+)
+
+// Various error messages describing possible errors when creating a Recurrence.
+const (
+	ErrInvalidEvery = "every must be greater than zero"
+	ErrInvalidDay   = "day must be between 1 and 31"
+	ErrInvalidWeek  = "week must be between 1 and 4, or -1 for the last week"
+)
+
+// Recurrence generates the times at which a repeating event occurs.
+type Recurrence interface {
+	// Occurrences returns the times of the occurrences that are after the
+	// after time and at or before the until time, in order.
+	Occurrences(after, until time.Time) []time.Time
+}
+
+// sequence is a Recurrence whose nth occurrence can be calculated directly.
+// nth must never decrease as n increases. Occurrences before start are
+// skipped, so nth can begin in the period that contains start.
+type sequence struct {
+	start time.Time
+	nth   func(n int) time.Time
+}
+
+// Occurrences ensures that sequence adheres to the Recurrence interface.
+func (s sequence) Occurrences(after, until time.Time) []time.Time {
+	var ts []time.Time
+	for n := 0; ; n++ {
+		t := s.nth(n)
+		if t.After(until) {
+			return ts
+		}
+		if t.Before(s.start) || !t.After(after) {
+			continue
+		}
+		ts = append(ts, t)
+	}
+}
+
+// Daily returns a Recurrence that occurs at start and every given number of
+// days after.
+func Daily(start time.Time, every int) (Recurrence, error) {
+	return interval(start, every, balance.Day)
+}
+
+// Weekly returns a Recurrence that occurs at start and every given number of
+// weeks after.
+func Weekly(start time.Time, every int) (Recurrence, error) {
+	return interval(start, every, balance.Week)
+}
+
+// Yearly returns a Recurrence that occurs at start and on the same day every
+// year after. An event starting on February 29th occurs on February 28th in
+// years that are not leap years.
+func Yearly(start time.Time) Recurrence {
+	r, _ := interval(start, 1, balance.Year)
+	return r
+}
+
+func interval(start time.Time, every int, i balance.Interval) (Recurrence, error) {
+	if every <= 0 {
+		return nil, errors.New(ErrInvalidEvery)
+	}
+	return sequence{
+		start: start,
+		nth:   func(n int) time.Time { return i.Add(start, n*every) },
+	}, nil
+}
+
+// MonthlyOnDay returns a Recurrence that occurs on the given day of every
+// month, from start, at the time of day of start. In months with fewer days,
+// the event occurs on the last day of the month.
+func MonthlyOnDay(start time.Time, day int) (Recurrence, error) {
+	if day < 1 || day > 31 {
+		return nil, errors.New(ErrInvalidDay)
+	}
+	return monthly(start, func(first time.Time) time.Time {
+		last := first.AddDate(0, 1, -1).Day()
+		if day < last {
+			return first.AddDate(0, 0, day-1)
+		}
+		return first.AddDate(0, 0, last-1)
+	}), nil
+}
+
+// MonthlyOnWeekday returns a Recurrence that occurs on the given week's
+// weekday of every month, from start, at the time of day of start. A week of
+// 1 is the first of that weekday in the month and a week of -1 is the last.
+func MonthlyOnWeekday(start time.Time, week int, wd time.Weekday) (Recurrence, error) {
+	if week == 0 || week < -1 || week > 4 {
+		return nil, errors.New(ErrInvalidWeek)
+	}
+	return monthly(start, func(first time.Time) time.Time {
+		return nthWeekday(first, week, wd)
+	}), nil
+}
+
+// LastWorkingDay returns a Recurrence that occurs on the last weekday, Monday
+// to Friday, of every month from start, at the time of day of start.
+// Public holidays are not taken into account.
+func LastWorkingDay(start time.Time) Recurrence {
+	return monthly(start, func(first time.Time) time.Time {
+		t := first.AddDate(0, 1, -1)
+		for t.Weekday() == time.Saturday || t.Weekday() == time.Sunday {
+			t = t.AddDate(0, 0, -1)
+		}
+		return t
+	})
+}
+
+// monthly returns a sequence with an occurrence in every month from the
+// month of start, given by the day function from the first day of the month.
+func monthly(start time.Time, day func(first time.Time) time.Time) sequence {
+	first := time.Date(start.Year(), start.Month(), 1, start.Hour(), start.Minute(), start.Second(), start.Nanosecond(), start.Location())
+	return sequence{
+		start: start,
+		nth:   func(n int) time.Time { return day(first.AddDate(0, n, 0)) },
+	}
+}
+
+// nthWeekday returns the given week's weekday of the month beginning at
+// first. A week of -1 gives the last of that weekday in the month.
+func nthWeekday(first time.Time, week int, wd time.Weekday) time.Time {
+	if week < 0 {
+		last := first.AddDate(0, 1, -1)
+		return last.AddDate(0, 0, -((int(last.Weekday()) - int(wd) + 7) % 7))
+	}
+	offset := (int(wd) - int(first.Weekday()) + 7) % 7
+	return first.AddDate(0, 0, offset+7*(week-1))
+}
+
+// Until returns a Recurrence that has the occurrences of the given Recurrence
+// up to and including the end time, and none after.
+func Until(r Recurrence, end time.Time) Recurrence {
+	return bounded{Recurrence: r, end: end}
+}
+
+type bounded struct {
+	Recurrence
+	end time.Time
+}
+
+// Occurrences ensures that bounded adheres to the Recurrence interface.
+func (b bounded) Occurrences(after, until time.Time) []time.Time {
+	if until.After(b.end) {
+		until = b.end
+	}
+	return b.Recurrence.Occurrences(after, until)
+}
@@ -0,0 +1,112 @@
+package schedule_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/glynternet/go-accounting/accountingtest"
+	"github.com/glynternet/go-accounting/schedule"
+	"github.com/stretchr/testify/assert"
+)
+
+// nineAM returns a time at 09:00 UTC on the given day, so that tests show
+// that the time of day of a start is kept.
+func nineAM(year int, month time.Month, day int) time.Time {
+	return accountingtest.Date(year, month, day).Add(9 * time.Hour)
+}
+
+func TestRecurrences(t *testing.T) {
+	every2Days, err := schedule.Daily(nineAM(2020, 1, 30), 2)
+	assert.Nil(t, err)
+	weekly, err := schedule.Weekly(nineAM(2020, 1, 1), 1)
+	assert.Nil(t, err)
+	onThe31st, err := schedule.MonthlyOnDay(nineAM(2020, 1, 1), 31)
+	assert.Nil(t, err)
+	onThe1st, err := schedule.MonthlyOnDay(nineAM(2020, 1, 15), 1)
+	assert.Nil(t, err)
+	secondTuesday, err := schedule.MonthlyOnWeekday(nineAM(2020, 1, 1), 2, time.Tuesday)
+	assert.Nil(t, err)
+	lastFriday, err := schedule.MonthlyOnWeekday(nineAM(2020, 1, 1), -1, time.Friday)
+	assert.Nil(t, err)
+
+	for _, test := range []struct {
+		name string
+		schedule.Recurrence
+		after, until time.Time
+		expected     []time.Time
+	}{
+		{
+			name:       "daily",
+			Recurrence: every2Days,
+			after:      nineAM(2020, 1, 1), until: nineAM(2020, 2, 5),
+			expected: []time.Time{nineAM(2020, 1, 30), nineAM(2020, 2, 1), nineAM(2020, 2, 3), nineAM(2020, 2, 5)},
+		},
+		{
+			name:       "weekly excludes after",
+			Recurrence: weekly,
+			after:      nineAM(2020, 1, 8), until: nineAM(2020, 1, 22),
+			expected: []time.Time{nineAM(2020, 1, 15), nineAM(2020, 1, 22)},
+		},
+		{
+			name:       "monthly clamps to month end",
+			Recurrence: onThe31st,
+			after:      nineAM(2019, 1, 1), until: nineAM(2020, 4, 30),
+			expected: []time.Time{nineAM(2020, 1, 31), nineAM(2020, 2, 29), nineAM(2020, 3, 31), nineAM(2020, 4, 30)},
+		},
+		{
+			name:       "monthly skips days before start",
+			Recurrence: onThe1st,
+			after:      nineAM(2019, 1, 1), until: nineAM(2020, 3, 1),
+			expected: []time.Time{nineAM(2020, 2, 1), nineAM(2020, 3, 1)},
+		},
+		{
+			name:       "second tuesday",
+			Recurrence: secondTuesday,
+			after:      nineAM(2019, 1, 1), until: nineAM(2020, 3, 31),
+			expected: []time.Time{nineAM(2020, 1, 14), nineAM(2020, 2, 11), nineAM(2020, 3, 10)},
+		},
+		{
+			name:       "last friday",
+			Recurrence: lastFriday,
+			after:      nineAM(2019, 1, 1), until: nineAM(2020, 3, 31),
+			expected: []time.Time{nineAM(2020, 1, 31), nineAM(2020, 2, 28), nineAM(2020, 3, 27)},
+		},
+		{
+			name:       "last working day",
+			Recurrence: schedule.LastWorkingDay(nineAM(2020, 1, 1)),
+			after:      nineAM(2019, 1, 1), until: nineAM(2020, 6, 30),
+			expected: []time.Time{
+				nineAM(2020, 1, 31), nineAM(2020, 2, 28), nineAM(2020, 3, 31),
+				nineAM(2020, 4, 30), nineAM(2020, 5, 29), nineAM(2020, 6, 30),
+			},
+		},
+		{
+			name:       "yearly from leap day",
+			Recurrence: schedule.Yearly(nineAM(2020, 2, 29)),
+			after:      nineAM(2019, 1, 1), until: nineAM(2024, 3, 1),
+			expected: []time.Time{nineAM(2020, 2, 29), nineAM(2021, 2, 28), nineAM(2022, 2, 28), nineAM(2023, 2, 28), nineAM(2024, 2, 29)},
+		},
+		{
+			name:       "until",
+			Recurrence: schedule.Until(weekly, nineAM(2020, 1, 14)),
+			after:      nineAM(2019, 1, 1), until: nineAM(2021, 1, 1),
+			expected: []time.Time{nineAM(2020, 1, 1), nineAM(2020, 1, 8)},
+		},
+	} {
+		assert.Equal(t, test.expected, test.Occurrences(test.after, test.until), test.name)
+	}
+}
+
+func TestRecurrences_Errors(t *testing.T) {
+	_, err := schedule.Daily(nineAM(2020, 1, 1), 0)
+	assert.Equal(t, errors.New(schedule.ErrInvalidEvery), err)
+	_, err = schedule.Weekly(nineAM(2020, 1, 1), -1)
+	assert.Equal(t, errors.New(schedule.ErrInvalidEvery), err)
+	_, err = schedule.MonthlyOnDay(nineAM(2020, 1, 1), 32)
+	assert.Equal(t, errors.New(schedule.ErrInvalidDay), err)
+	_, err = schedule.MonthlyOnWeekday(nineAM(2020, 1, 1), 5, time.Monday)
+	assert.Equal(t, errors.New(schedule.ErrInvalidWeek), err)
+	_, err = schedule.MonthlyOnWeekday(nineAM(2020, 1, 1), 0, time.Monday)
+	assert.Equal(t, errors.New(schedule.ErrInvalidWeek), err)
+}
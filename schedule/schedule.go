@@ -0,0 +1,66 @@
+// Package schedule forecasts the future Balances of an Account from Rules
+// that describe recurring changes to its Amount, such as salary and rent.
+package schedule
+
+import (
+	"sort"
+	"time"
+
+	"github.com/glynternet/go-accounting/account"
+	"github.com/glynternet/go-accounting/balance"
+)
+
+// Rule describes an Amount that is added to the Balance of an Account at each
+// occurrence of a Recurrence. Outgoing amounts are negative.
+type Rule struct {
+	Description string
+	Amount      int
+	Recurrence  Recurrence
+}
+
+// Forecast returns the Balances of an Account that are expected from applying
+// the given Rules, starting from the latest of the given Balances and
+// continuing up to and including the horizon.
+// A Balance is returned for each time that at least one Rule occurs, holding
+// the Amount of the latest Balance plus all of the Rule Amounts that have
+// occurred since. Rules that occur at the same time are applied together.
+// The forecast never extends beyond the time that the Account is closed.
+// Forecast returns an error if there are no Balances or the latest Balance is
+// invalid for the Account.
+func Forecast(a account.Account, bs balance.Balances, horizon time.Time, rs ...Rule) (balance.Balances, error) {
+	latest, err := bs.Latest()
+	if err != nil {
+		return nil, err
+	}
+	if err := a.ValidateBalance(latest); err != nil {
+		return nil, err
+	}
+	if a.Closed().Valid && horizon.After(a.Closed().Time) {
+		horizon = a.Closed().Time
+	}
+
+	var occurrences balance.Balances
+	for _, r := range rs {
+		for _, t := range r.Recurrence.Occurrences(latest.Date, horizon) {
+			occurrences = append(occurrences, balance.Balance{Date: t, Amount: r.Amount})
+		}
+	}
+	sort.SliceStable(occurrences, func(i, j int) bool {
+		return occurrences[i].Date.Before(occurrences[j].Date)
+	})
+
+	var forecast balance.Balances
+	amount := latest.Amount
+	for i, o := range occurrences {
+		amount += o.Amount
+		if i+1 < len(occurrences) && occurrences[i+1].Date.Equal(o.Date) {
+			continue
+		}
+		forecast = append(forecast, balance.Balance{
+			Date:     o.Date,
+			Amount:   amount,
+			Currency: a.CurrencyCode(),
+		})
+	}
+	return forecast, nil
+}
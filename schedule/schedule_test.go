@@ -0,0 +1,74 @@
+package schedule_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/glynternet/go-accounting/account"
+	"github.com/glynternet/go-accounting/accountingtest"
+	"github.com/glynternet/go-accounting/balance"
+	"github.com/glynternet/go-accounting/schedule"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestForecast(t *testing.T) {
+	gbp := accountingtest.NewCurrencyCode(t, "GBP")
+	a := *accountingtest.NewAccount(t, "Current", gbp, nineAM(2019, 1, 1))
+	rent, err := schedule.MonthlyOnDay(nineAM(2020, 1, 1), 1)
+	assert.Nil(t, err)
+	rules := []schedule.Rule{
+		{Description: "salary", Amount: 2500, Recurrence: schedule.LastWorkingDay(nineAM(2020, 1, 1))},
+		{Description: "rent", Amount: -1200, Recurrence: rent},
+	}
+	bs := balance.Balances{
+		{Date: nineAM(2020, 1, 1), Amount: 100, Currency: gbp},
+		{Date: nineAM(2020, 1, 15), Amount: 500, Currency: gbp},
+	}
+
+	forecast, err := schedule.Forecast(a, bs, nineAM(2020, 3, 1), rules...)
+	assert.Nil(t, err)
+	assert.Equal(t, balance.Balances{
+		{Date: nineAM(2020, 1, 31), Amount: 3000, Currency: gbp},
+		{Date: nineAM(2020, 2, 1), Amount: 1800, Currency: gbp},
+		{Date: nineAM(2020, 2, 28), Amount: 4300, Currency: gbp},
+		{Date: nineAM(2020, 3, 1), Amount: 3100, Currency: gbp},
+	}, forecast)
+}
+
+func TestForecast_SameTime(t *testing.T) {
+	a := *accountingtest.NewAccount(t, "Current", accountingtest.NewCurrencyCode(t, "GBP"), nineAM(2019, 1, 1))
+	daily, err := schedule.Daily(nineAM(2020, 1, 2), 1)
+	assert.Nil(t, err)
+	forecast, err := schedule.Forecast(a, balance.Balances{{Date: nineAM(2020, 1, 1), Amount: 0}}, nineAM(2020, 1, 3),
+		schedule.Rule{Amount: 10, Recurrence: daily},
+		schedule.Rule{Amount: -3, Recurrence: daily},
+	)
+	assert.Nil(t, err)
+	assert.Equal(t, balance.Balances{
+		{Date: nineAM(2020, 1, 2), Amount: 7, Currency: a.CurrencyCode()},
+		{Date: nineAM(2020, 1, 3), Amount: 14, Currency: a.CurrencyCode()},
+	}, forecast)
+}
+
+func TestForecast_ClosedAccount(t *testing.T) {
+	a := *accountingtest.NewAccount(t, "Current", accountingtest.NewCurrencyCode(t, "GBP"), nineAM(2019, 1, 1), account.CloseTime(nineAM(2020, 1, 3)))
+	daily, err := schedule.Daily(nineAM(2020, 1, 1), 1)
+	assert.Nil(t, err)
+	forecast, err := schedule.Forecast(a, balance.Balances{{Date: nineAM(2020, 1, 1), Amount: 0}}, nineAM(2021, 1, 1),
+		schedule.Rule{Amount: 1, Recurrence: daily},
+	)
+	assert.Nil(t, err)
+	assert.Len(t, forecast, 2)
+	for _, b := range forecast {
+		assert.Nil(t, a.ValidateBalance(b))
+	}
+}
+
+func TestForecast_Errors(t *testing.T) {
+	a := *accountingtest.NewAccount(t, "Current", accountingtest.NewCurrencyCode(t, "GBP"), nineAM(2020, 1, 1))
+	_, err := schedule.Forecast(a, nil, nineAM(2021, 1, 1))
+	assert.Equal(t, errors.New(balance.ErrEmptyBalancesMessage), err)
+
+	_, err = schedule.Forecast(a, balance.Balances{{Date: nineAM(2019, 1, 1)}}, nineAM(2021, 1, 1))
+	assert.IsType(t, balance.DateOutOfAccountTimeRange{}, err)
+}
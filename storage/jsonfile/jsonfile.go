@@ -0,0 +1,248 @@
+package jsonfile
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+	"sync"
+
+	"github.com/glynternet/go-accounting/account"
+	"github.com/glynternet/go-accounting/balance"
+	"github.com/glynternet/go-accounting/storage"
+	pkgerrors "github.com/pkg/errors"
+)
+
+// New creates a Storage that persists all Accounts and Balances to the JSON file at the given path.
+// If the file exists, its contents are loaded and every Account and Balance
+// is validated, returning an error if any are invalid. If the file does not
+// exist, it will be created on the first write.
+func New(path string) (*Storage, error) {
+	s := &Storage{path: path}
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, pkgerrors.Wrapf(err, "reading file %s", path)
+	}
+	if err := json.Unmarshal(data, &s.doc); err != nil {
+		return nil, pkgerrors.Wrapf(err, "unmarshalling file %s", path)
+	}
+	for _, r := range s.doc.Accounts {
+		a, err := storage.NewAccount(r.Account)
+		if err != nil {
+			return nil, pkgerrors.Wrapf(err, "validating Account %d", r.ID)
+		}
+		for _, b := range r.Balances {
+			if err := a.ValidateBalance(b.Balance); err != nil {
+				return nil, pkgerrors.Wrapf(err, "validating Balance %d of Account %d", b.ID, r.ID)
+			}
+		}
+	}
+	return s, nil
+}
+
+// Storage is an implementation of storage.Storage that persists all Accounts
+// and Balances to a single JSON file, rewriting the file on every write.
+// Storage is safe for concurrent use within a single process.
+type Storage struct {
+	path   string
+	mu     sync.RWMutex
+	closed bool
+	doc    document
+}
+
+type document struct {
+	LastAccountID uint64
+	LastBalanceID uint64
+	Accounts      []record
+}
+
+type record struct {
+	ID       uint64
+	Account  account.Account
+	Balances storage.Balances
+}
+
+func (d document) index(id uint64) (int, error) {
+	for i, r := range d.Accounts {
+		if r.ID == id {
+			return i, nil
+		}
+	}
+	return 0, errors.New(storage.ErrAccountNotFound)
+}
+
+// InsertAccount stores a new Account, returning the stored Account with its assigned ID.
+func (s *Storage) InsertAccount(a account.Account) (*storage.Account, error) {
+	valid, err := storage.NewAccount(a)
+	if err != nil {
+		return nil, err
+	}
+	var stored storage.Account
+	err = s.update(func(d *document) error {
+		d.LastAccountID++
+		stored = storage.Account{ID: d.LastAccountID, Account: *valid}
+		d.Accounts = append(d.Accounts, record{ID: stored.ID, Account: stored.Account})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &stored, nil
+}
+
+// SelectAccount returns the Account stored with the given ID.
+func (s *Storage) SelectAccount(id uint64) (*storage.Account, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.closed {
+		return nil, errors.New(storage.ErrClosed)
+	}
+	i, err := s.doc.index(id)
+	if err != nil {
+		return nil, err
+	}
+	r := s.doc.Accounts[i]
+	return &storage.Account{ID: r.ID, Account: r.Account}, nil
+}
+
+// SelectAccounts returns all stored Accounts in the order that they were inserted.
+func (s *Storage) SelectAccounts() (storage.Accounts, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.closed {
+		return nil, errors.New(storage.ErrClosed)
+	}
+	var as storage.Accounts
+	for _, r := range s.doc.Accounts {
+		as = append(as, storage.Account{ID: r.ID, Account: r.Account})
+	}
+	return as, nil
+}
+
+// UpdateAccount replaces the Account stored with the given ID.
+// UpdateAccount returns an error if the updated Account is invalid or if any
+// of the Balances already stored for the Account are invalid for the updated
+// Account, in which case the stored Account is not altered.
+func (s *Storage) UpdateAccount(id uint64, updates account.Account) (*storage.Account, error) {
+	valid, err := storage.NewAccount(updates)
+	if err != nil {
+		return nil, err
+	}
+	err = s.update(func(d *document) error {
+		i, err := d.index(id)
+		if err != nil {
+			return err
+		}
+		for _, b := range d.Accounts[i].Balances {
+			if err := valid.ValidateBalance(b.Balance); err != nil {
+				return err
+			}
+		}
+		d.Accounts[i].Account = *valid
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &storage.Account{ID: id, Account: *valid}, nil
+}
+
+// DeleteAccount removes the Account stored with the given ID, along with all of its Balances.
+func (s *Storage) DeleteAccount(id uint64) error {
+	return s.update(func(d *document) error {
+		i, err := d.index(id)
+		if err != nil {
+			return err
+		}
+		d.Accounts = append(d.Accounts[:i], d.Accounts[i+1:]...)
+		return nil
+	})
+}
+
+// InsertBalance stores a new Balance for the Account with the given ID,
+// returning the stored Balance with its assigned ID.
+// The Balance is validated through Account.ValidateBalance before being stored.
+func (s *Storage) InsertBalance(accountID uint64, b balance.Balance) (*storage.Balance, error) {
+	var stored storage.Balance
+	err := s.update(func(d *document) error {
+		i, err := d.index(accountID)
+		if err != nil {
+			return err
+		}
+		if err := d.Accounts[i].Account.ValidateBalance(b); err != nil {
+			return err
+		}
+		d.LastBalanceID++
+		stored = storage.Balance{ID: d.LastBalanceID, Balance: b}
+		d.Accounts[i].Balances = append(d.Accounts[i].Balances, stored)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &stored, nil
+}
+
+// SelectAccountBalances returns all Balances stored for the Account with the
+// given ID, in the order that they were inserted.
+func (s *Storage) SelectAccountBalances(accountID uint64) (storage.Balances, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.closed {
+		return nil, errors.New(storage.ErrClosed)
+	}
+	i, err := s.doc.index(accountID)
+	if err != nil {
+		return nil, err
+	}
+	return append(storage.Balances(nil), s.doc.Accounts[i].Balances...), nil
+}
+
+// Close closes the Storage, after which all operations will return an error.
+func (s *Storage) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.closed = true
+	return nil
+}
+
+// update applies fn to a copy of the document and writes the result to the
+// file, only replacing the document of the Storage if both succeed.
+func (s *Storage) update(fn func(*document) error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return errors.New(storage.ErrClosed)
+	}
+	d := s.doc
+	d.Accounts = make([]record, len(s.doc.Accounts))
+	for i, r := range s.doc.Accounts {
+		r.Balances = append(storage.Balances(nil), r.Balances...)
+		d.Accounts[i] = r
+	}
+	if err := fn(&d); err != nil {
+		return err
+	}
+	if err := d.write(s.path); err != nil {
+		return err
+	}
+	s.doc = d
+	return nil
+}
+
+// write writes the document to a temporary file before renaming it to the
+// given path, so that the file at path is never left partially written.
+func (d document) write(path string) error {
+	data, err := json.MarshalIndent(d, "", "\t")
+	if err != nil {
+		return pkgerrors.Wrap(err, "marshalling document")
+	}
+	tmp := path + ".tmp"
+	if err := ioutil.WriteFile(tmp, data, 0600); err != nil {
+		return pkgerrors.Wrapf(err, "writing file %s", tmp)
+	}
+	return pkgerrors.Wrapf(os.Rename(tmp, path), "renaming %s to %s", tmp, path)
+}
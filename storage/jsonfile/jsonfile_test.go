@@ -0,0 +1,104 @@
+package jsonfile_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/glynternet/go-accounting/accountingtest"
+	"github.com/glynternet/go-accounting/balance"
+	"github.com/glynternet/go-accounting/storage"
+	"github.com/glynternet/go-accounting/storage/jsonfile"
+	"github.com/glynternet/go-accounting/storage/storagetest"
+	"github.com/glynternet/go-money/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStorage(t *testing.T) {
+	path, cleanup := newTestPath(t)
+	defer cleanup()
+	s, err := jsonfile.New(path)
+	common.FatalIfError(t, err, "creating Storage")
+	storagetest.Test(t, s)
+}
+
+func TestStorage_Persistence(t *testing.T) {
+	path, cleanup := newTestPath(t)
+	defer cleanup()
+	s, err := jsonfile.New(path)
+	common.FatalIfError(t, err, "creating Storage")
+	open := time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+	a, err := s.InsertAccount(*accountingtest.NewAccount(t, "A", accountingtest.NewCurrencyCode(t, "GBP"), open))
+	common.FatalIfError(t, err, "inserting Account")
+	b, err := s.InsertBalance(a.ID, balance.Balance{Date: open, Amount: 123})
+	common.FatalIfError(t, err, "inserting Balance")
+	common.FatalIfError(t, s.Close(), "closing Storage")
+
+	s, err = jsonfile.New(path)
+	common.FatalIfError(t, err, "reopening Storage")
+	as, err := s.SelectAccounts()
+	common.FatalIfError(t, err, "selecting Accounts")
+	if assert.Len(t, as, 1) {
+		assert.True(t, a.Equal(as[0]))
+	}
+	bs, err := s.SelectAccountBalances(a.ID)
+	common.FatalIfError(t, err, "selecting Balances")
+	if assert.Len(t, bs, 1) {
+		assert.Equal(t, b.ID, bs[0].ID)
+		assert.True(t, b.Balance.Equal(bs[0].Balance))
+	}
+
+	next, err := s.InsertAccount(*accountingtest.NewAccount(t, "B", accountingtest.NewCurrencyCode(t, "GBP"), open))
+	common.FatalIfError(t, err, "inserting Account after reopening")
+	assert.NotEqual(t, a.ID, next.ID)
+}
+
+func TestNew_InvalidFile(t *testing.T) {
+	for _, test := range []struct {
+		name    string
+		content string
+	}{
+		{
+			name:    "invalid json",
+			content: "{",
+		},
+		{
+			name:    "invalid account",
+			content: `{"Accounts":[{"ID":1,"Account":{"Name":"","Currency":"GBP"}}]}`,
+		},
+		{
+			name: "balance outside of account time range",
+			content: `{"Accounts":[{"ID":1,"Account":{"Name":"A","Currency":"GBP","Opened":"2000-01-01T00:00:00Z"},` +
+				`"Balances":[{"ID":1,"Date":"1999-01-01T00:00:00Z","Amount":1}]}]}`,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			path, cleanup := newTestPath(t)
+			defer cleanup()
+			common.FatalIfError(t, ioutil.WriteFile(path, []byte(test.content), 0600), "writing file")
+			s, err := jsonfile.New(path)
+			assert.Error(t, err)
+			assert.Nil(t, s)
+		})
+	}
+}
+
+func TestStorage_Close(t *testing.T) {
+	path, cleanup := newTestPath(t)
+	defer cleanup()
+	s, err := jsonfile.New(path)
+	common.FatalIfError(t, err, "creating Storage")
+	assert.Nil(t, s.Close())
+	_, err = s.SelectAccounts()
+	assert.EqualError(t, err, storage.ErrClosed)
+}
+
+func newTestPath(t *testing.T) (string, func()) {
+	dir, err := ioutil.TempDir("", "jsonfile")
+	common.FatalIfError(t, err, "creating temporary directory")
+	return filepath.Join(dir, "storage.json"), func() {
+		common.ErrorIfError(t, os.RemoveAll(dir), "removing temporary directory")
+	}
+}
@@ -0,0 +1,166 @@
+package memory
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/glynternet/go-accounting/account"
+	"github.com/glynternet/go-accounting/balance"
+	"github.com/glynternet/go-accounting/storage"
+)
+
+// New creates a new, empty in-memory Storage.
+func New() *Storage {
+	return &Storage{balances: make(map[uint64]storage.Balances)}
+}
+
+// Storage is an implementation of storage.Storage that holds all Accounts and
+// Balances in memory.
+// Storage is safe for concurrent use.
+type Storage struct {
+	mu            sync.RWMutex
+	closed        bool
+	accounts      storage.Accounts
+	balances      map[uint64]storage.Balances
+	lastAccountID uint64
+	lastBalanceID uint64
+}
+
+// InsertAccount stores a new Account, returning the stored Account with its assigned ID.
+func (s *Storage) InsertAccount(a account.Account) (*storage.Account, error) {
+	valid, err := storage.NewAccount(a)
+	if err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return nil, errors.New(storage.ErrClosed)
+	}
+	s.lastAccountID++
+	stored := storage.Account{ID: s.lastAccountID, Account: *valid}
+	s.accounts = append(s.accounts, stored)
+	return &stored, nil
+}
+
+// SelectAccount returns the Account stored with the given ID.
+func (s *Storage) SelectAccount(id uint64) (*storage.Account, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.closed {
+		return nil, errors.New(storage.ErrClosed)
+	}
+	i, err := s.index(id)
+	if err != nil {
+		return nil, err
+	}
+	a := s.accounts[i]
+	return &a, nil
+}
+
+// SelectAccounts returns all stored Accounts in the order that they were inserted.
+func (s *Storage) SelectAccounts() (storage.Accounts, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.closed {
+		return nil, errors.New(storage.ErrClosed)
+	}
+	return append(storage.Accounts(nil), s.accounts...), nil
+}
+
+// UpdateAccount replaces the Account stored with the given ID.
+// UpdateAccount returns an error if the updated Account is invalid or if any
+// of the Balances already stored for the Account are invalid for the updated
+// Account, in which case the stored Account is not altered.
+func (s *Storage) UpdateAccount(id uint64, updates account.Account) (*storage.Account, error) {
+	valid, err := storage.NewAccount(updates)
+	if err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return nil, errors.New(storage.ErrClosed)
+	}
+	i, err := s.index(id)
+	if err != nil {
+		return nil, err
+	}
+	for _, b := range s.balances[id] {
+		if err := valid.ValidateBalance(b.Balance); err != nil {
+			return nil, err
+		}
+	}
+	s.accounts[i].Account = *valid
+	a := s.accounts[i]
+	return &a, nil
+}
+
+// DeleteAccount removes the Account stored with the given ID, along with all of its Balances.
+func (s *Storage) DeleteAccount(id uint64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return errors.New(storage.ErrClosed)
+	}
+	i, err := s.index(id)
+	if err != nil {
+		return err
+	}
+	s.accounts = append(s.accounts[:i], s.accounts[i+1:]...)
+	delete(s.balances, id)
+	return nil
+}
+
+// InsertBalance stores a new Balance for the Account with the given ID,
+// returning the stored Balance with its assigned ID.
+// The Balance is validated through Account.ValidateBalance before being stored.
+func (s *Storage) InsertBalance(accountID uint64, b balance.Balance) (*storage.Balance, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return nil, errors.New(storage.ErrClosed)
+	}
+	i, err := s.index(accountID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.accounts[i].Account.ValidateBalance(b); err != nil {
+		return nil, err
+	}
+	s.lastBalanceID++
+	stored := storage.Balance{ID: s.lastBalanceID, Balance: b}
+	s.balances[accountID] = append(s.balances[accountID], stored)
+	return &stored, nil
+}
+
+// SelectAccountBalances returns all Balances stored for the Account with the
+// given ID, in the order that they were inserted.
+func (s *Storage) SelectAccountBalances(accountID uint64) (storage.Balances, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.closed {
+		return nil, errors.New(storage.ErrClosed)
+	}
+	if _, err := s.index(accountID); err != nil {
+		return nil, err
+	}
+	return append(storage.Balances(nil), s.balances[accountID]...), nil
+}
+
+// Close closes the Storage, after which all operations will return an error.
+func (s *Storage) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.closed = true
+	return nil
+}
+
+func (s *Storage) index(id uint64) (int, error) {
+	for i, a := range s.accounts {
+		if a.ID == id {
+			return i, nil
+		}
+	}
+	return 0, errors.New(storage.ErrAccountNotFound)
+}
@@ -0,0 +1,21 @@
+package memory_test
+
+import (
+	"testing"
+
+	"github.com/glynternet/go-accounting/storage"
+	"github.com/glynternet/go-accounting/storage/memory"
+	"github.com/glynternet/go-accounting/storage/storagetest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStorage(t *testing.T) {
+	storagetest.Test(t, memory.New())
+}
+
+func TestStorage_Close(t *testing.T) {
+	s := memory.New()
+	assert.Nil(t, s.Close())
+	_, err := s.SelectAccounts()
+	assert.EqualError(t, err, storage.ErrClosed)
+}
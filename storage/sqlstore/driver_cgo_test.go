@@ -0,0 +1,10 @@
+//go:build sqlite_cgo
+// +build sqlite_cgo
+
+package sqlstore_test
+
+import _ "github.com/mattn/go-sqlite3"
+
+// driverName is the name of the cgo SQLite driver, used in place of the
+// pure-Go driver when building with the sqlite_cgo tag.
+const driverName = "sqlite3"
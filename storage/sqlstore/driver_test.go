@@ -0,0 +1,11 @@
+//go:build !sqlite_cgo
+// +build !sqlite_cgo
+
+package sqlstore_test
+
+import _ "modernc.org/sqlite"
+
+// driverName is the name of the pure-Go SQLite driver, so that the tests can
+// run without cgo or an external database.
+// Build with the sqlite_cgo tag to test against the cgo driver instead.
+const driverName = "sqlite"
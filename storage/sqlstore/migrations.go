@@ -0,0 +1,66 @@
+package sqlstore
+
+import (
+	"database/sql"
+
+	"github.com/pkg/errors"
+)
+
+// migrations holds the statements that build the schema of a Storage, in the
+// order that they must be applied. Once released, a migration must never be
+// altered; changes to the schema must be made by appending a new migration.
+var migrations = []string{
+	`CREATE TABLE accounts (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		name TEXT NOT NULL,
+		currency TEXT NOT NULL,
+		opened TEXT NOT NULL,
+		closed TEXT NULL,
+		type TEXT NOT NULL
+	)`,
+	`CREATE TABLE balances (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		account_id INTEGER NOT NULL REFERENCES accounts(id),
+		date TEXT NOT NULL,
+		amount INTEGER NOT NULL,
+		currency TEXT NULL
+	)`,
+	`CREATE INDEX balances_account_id ON balances (account_id)`,
+}
+
+// migrate applies any of the migrations that have not yet been applied to
+// the database, recording the version of the schema in the schema_version
+// table. Each migration is applied in its own transaction.
+func migrate(db *sql.DB) error {
+	_, err := db.Exec(`CREATE TABLE IF NOT EXISTS schema_version (version INTEGER NOT NULL)`)
+	if err != nil {
+		return errors.Wrap(err, "creating schema_version table")
+	}
+	var version int
+	err = db.QueryRow(`SELECT COALESCE(MAX(version), 0) FROM schema_version`).Scan(&version)
+	if err != nil {
+		return errors.Wrap(err, "selecting schema version")
+	}
+	for i := version; i < len(migrations); i++ {
+		if err := apply(db, i+1, migrations[i]); err != nil {
+			return errors.Wrapf(err, "applying migration %d", i+1)
+		}
+	}
+	return nil
+}
+
+func apply(db *sql.DB, version int, migration string) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	if _, err := tx.Exec(migration); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	if _, err := tx.Exec(`INSERT INTO schema_version (version) VALUES (?)`, version); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
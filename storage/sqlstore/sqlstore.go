@@ -0,0 +1,325 @@
+package sqlstore
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/glynternet/go-accounting/account"
+	"github.com/glynternet/go-accounting/balance"
+	"github.com/glynternet/go-accounting/storage"
+	"github.com/glynternet/go-money/currency"
+	"github.com/pkg/errors"
+)
+
+// timeLayout is the layout used to store times as text, so that they sort
+// correctly and round-trip at full precision.
+const timeLayout = "2006-01-02T15:04:05.000000000Z07:00"
+
+// Open opens a database with the given driver and data source name and
+// returns a Storage that uses it.
+// The database driver must be registered with database/sql before calling Open.
+func Open(driverName, dataSourceName string) (*Storage, error) {
+	db, err := sql.Open(driverName, dataSourceName)
+	if err != nil {
+		return nil, errors.Wrap(err, "opening database")
+	}
+	s, err := New(db)
+	if err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+	return s, nil
+}
+
+// New creates a Storage that uses the given database, applying any schema
+// migrations that have not yet been applied.
+// The statements used by Storage use ? placeholders and have been tested
+// against SQLite.
+func New(db *sql.DB) (*Storage, error) {
+	if err := migrate(db); err != nil {
+		return nil, errors.Wrap(err, "migrating database")
+	}
+	return &Storage{db: db}, nil
+}
+
+// Storage is an implementation of storage.Storage that uses a database/sql database.
+type Storage struct {
+	db *sql.DB
+}
+
+// InsertAccount stores a new Account, returning the stored Account with its assigned ID.
+func (s *Storage) InsertAccount(a account.Account) (*storage.Account, error) {
+	valid, err := storage.NewAccount(a)
+	if err != nil {
+		return nil, err
+	}
+	res, err := s.db.Exec(
+		`INSERT INTO accounts (name, currency, opened, closed, type) VALUES (?, ?, ?, ?, ?)`,
+		accountValues(*valid)...,
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "inserting Account")
+	}
+	id, err := res.LastInsertId()
+	if err != nil {
+		return nil, errors.Wrap(err, "getting inserted Account ID")
+	}
+	return &storage.Account{ID: uint64(id), Account: *valid}, nil
+}
+
+// SelectAccount returns the Account stored with the given ID.
+func (s *Storage) SelectAccount(id uint64) (*storage.Account, error) {
+	return selectAccount(s.db, id)
+}
+
+// SelectAccounts returns all stored Accounts in the order that they were inserted.
+func (s *Storage) SelectAccounts() (storage.Accounts, error) {
+	rows, err := s.db.Query(`SELECT id, name, currency, opened, closed, type FROM accounts ORDER BY id`)
+	if err != nil {
+		return nil, errors.Wrap(err, "selecting Accounts")
+	}
+	defer rows.Close()
+	var as storage.Accounts
+	for rows.Next() {
+		a, err := scanAccount(rows)
+		if err != nil {
+			return nil, err
+		}
+		as = append(as, *a)
+	}
+	return as, errors.Wrap(rows.Err(), "iterating Account rows")
+}
+
+// UpdateAccount replaces the Account stored with the given ID.
+// UpdateAccount returns an error if the updated Account is invalid or if any
+// of the Balances already stored for the Account are invalid for the updated
+// Account, in which case the stored Account is not altered.
+func (s *Storage) UpdateAccount(id uint64, updates account.Account) (*storage.Account, error) {
+	valid, err := storage.NewAccount(updates)
+	if err != nil {
+		return nil, err
+	}
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, errors.Wrap(err, "beginning transaction")
+	}
+	if _, err := selectAccount(tx, id); err != nil {
+		_ = tx.Rollback()
+		return nil, err
+	}
+	bs, err := selectBalances(tx, id)
+	if err != nil {
+		_ = tx.Rollback()
+		return nil, err
+	}
+	for _, b := range bs {
+		if err := valid.ValidateBalance(b.Balance); err != nil {
+			_ = tx.Rollback()
+			return nil, err
+		}
+	}
+	_, err = tx.Exec(
+		`UPDATE accounts SET name = ?, currency = ?, opened = ?, closed = ?, type = ? WHERE id = ?`,
+		append(accountValues(*valid), id)...,
+	)
+	if err != nil {
+		_ = tx.Rollback()
+		return nil, errors.Wrap(err, "updating Account")
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, errors.Wrap(err, "committing transaction")
+	}
+	return &storage.Account{ID: id, Account: *valid}, nil
+}
+
+// DeleteAccount removes the Account stored with the given ID, along with all of its Balances.
+func (s *Storage) DeleteAccount(id uint64) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return errors.Wrap(err, "beginning transaction")
+	}
+	if _, err := tx.Exec(`DELETE FROM balances WHERE account_id = ?`, id); err != nil {
+		_ = tx.Rollback()
+		return errors.Wrap(err, "deleting Balances")
+	}
+	res, err := tx.Exec(`DELETE FROM accounts WHERE id = ?`, id)
+	if err != nil {
+		_ = tx.Rollback()
+		return errors.Wrap(err, "deleting Account")
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		_ = tx.Rollback()
+		return errors.Wrap(err, "getting deleted Account count")
+	}
+	if n == 0 {
+		_ = tx.Rollback()
+		return errors.New(storage.ErrAccountNotFound)
+	}
+	return errors.Wrap(tx.Commit(), "committing transaction")
+}
+
+// InsertBalance stores a new Balance for the Account with the given ID,
+// returning the stored Balance with its assigned ID.
+// The Balance is validated through Account.ValidateBalance before being
+// stored, so that its Date is within the TimeRange of the Account.
+func (s *Storage) InsertBalance(accountID uint64, b balance.Balance) (*storage.Balance, error) {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, errors.Wrap(err, "beginning transaction")
+	}
+	a, err := selectAccount(tx, accountID)
+	if err != nil {
+		_ = tx.Rollback()
+		return nil, err
+	}
+	if err := a.Account.ValidateBalance(b); err != nil {
+		_ = tx.Rollback()
+		return nil, err
+	}
+	var c sql.NullString
+	if b.HasCurrency() {
+		c = sql.NullString{String: fmt.Sprint(b.Currency), Valid: true}
+	}
+	res, err := tx.Exec(
+		`INSERT INTO balances (account_id, date, amount, currency) VALUES (?, ?, ?, ?)`,
+		accountID, formatTime(b.Date), b.Amount, c,
+	)
+	if err != nil {
+		_ = tx.Rollback()
+		return nil, errors.Wrap(err, "inserting Balance")
+	}
+	id, err := res.LastInsertId()
+	if err != nil {
+		_ = tx.Rollback()
+		return nil, errors.Wrap(err, "getting inserted Balance ID")
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, errors.Wrap(err, "committing transaction")
+	}
+	return &storage.Balance{ID: uint64(id), Balance: b}, nil
+}
+
+// SelectAccountBalances returns all Balances stored for the Account with the
+// given ID, in the order that they were inserted.
+func (s *Storage) SelectAccountBalances(accountID uint64) (storage.Balances, error) {
+	if _, err := selectAccount(s.db, accountID); err != nil {
+		return nil, err
+	}
+	return selectBalances(s.db, accountID)
+}
+
+// Close closes the underlying database.
+func (s *Storage) Close() error {
+	return s.db.Close()
+}
+
+// queryer is satisfied by both *sql.DB and *sql.Tx.
+type queryer interface {
+	Query(query string, args ...interface{}) (*sql.Rows, error)
+	QueryRow(query string, args ...interface{}) *sql.Row
+}
+
+// scanner is satisfied by both *sql.Row and *sql.Rows.
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func selectAccount(q queryer, id uint64) (*storage.Account, error) {
+	row := q.QueryRow(`SELECT id, name, currency, opened, closed, type FROM accounts WHERE id = ?`, id)
+	a, err := scanAccount(row)
+	if errors.Cause(err) == sql.ErrNoRows {
+		return nil, errors.New(storage.ErrAccountNotFound)
+	}
+	return a, err
+}
+
+func scanAccount(s scanner) (*storage.Account, error) {
+	var (
+		id                  uint64
+		name, code, opened  string
+		accountType         string
+		closed              sql.NullString
+		closeTime, openTime time.Time
+	)
+	if err := s.Scan(&id, &name, &code, &opened, &closed, &accountType); err != nil {
+		return nil, errors.Wrap(err, "scanning Account row")
+	}
+	c, err := currency.NewCode(code)
+	if err != nil {
+		return nil, errors.Wrapf(err, "parsing currency of Account %d", id)
+	}
+	openTime, err = parseTime(opened)
+	if err != nil {
+		return nil, errors.Wrapf(err, "parsing opened time of Account %d", id)
+	}
+	if closed.Valid {
+		closeTime, err = parseTime(closed.String)
+		if err != nil {
+			return nil, errors.Wrapf(err, "parsing closed time of Account %d", id)
+		}
+	}
+	t, err := account.ParseType(accountType)
+	if err != nil {
+		return nil, errors.Wrapf(err, "parsing type of Account %d", id)
+	}
+	a, err := account.New(name, *c, openTime, account.CloseTime(closeTime), account.OfType(t))
+	if err != nil {
+		return nil, errors.Wrapf(err, "creating Account %d", id)
+	}
+	return &storage.Account{ID: id, Account: *a}, nil
+}
+
+func selectBalances(q queryer, accountID uint64) (storage.Balances, error) {
+	rows, err := q.Query(`SELECT id, date, amount, currency FROM balances WHERE account_id = ? ORDER BY id`, accountID)
+	if err != nil {
+		return nil, errors.Wrap(err, "selecting Balances")
+	}
+	defer rows.Close()
+	var bs storage.Balances
+	for rows.Next() {
+		var (
+			b    storage.Balance
+			date string
+			code sql.NullString
+		)
+		if err := rows.Scan(&b.ID, &date, &b.Amount, &code); err != nil {
+			return nil, errors.Wrap(err, "scanning Balance row")
+		}
+		if b.Date, err = parseTime(date); err != nil {
+			return nil, errors.Wrapf(err, "parsing date of Balance %d", b.ID)
+		}
+		if code.Valid {
+			c, err := currency.NewCode(code.String)
+			if err != nil {
+				return nil, errors.Wrapf(err, "parsing currency of Balance %d", b.ID)
+			}
+			b.Currency = *c
+		}
+		bs = append(bs, b)
+	}
+	return bs, errors.Wrap(rows.Err(), "iterating Balance rows")
+}
+
+func accountValues(a account.Account) []interface{} {
+	var closed sql.NullString
+	if a.Closed().Valid {
+		closed = sql.NullString{String: formatTime(a.Closed().Time), Valid: true}
+	}
+	return []interface{}{
+		a.Name(),
+		fmt.Sprint(a.CurrencyCode()),
+		formatTime(a.Opened()),
+		closed,
+		a.Type().String(),
+	}
+}
+
+func formatTime(t time.Time) string {
+	return t.UTC().Format(timeLayout)
+}
+
+func parseTime(s string) (time.Time, error) {
+	return time.Parse(timeLayout, s)
+}
@@ -0,0 +1,78 @@
+package sqlstore_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/glynternet/go-accounting/account"
+	"github.com/glynternet/go-accounting/accountingtest"
+	"github.com/glynternet/go-accounting/balance"
+	"github.com/glynternet/go-accounting/storage/sqlstore"
+	"github.com/glynternet/go-accounting/storage/storagetest"
+	"github.com/glynternet/go-money/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStorage(t *testing.T) {
+	path, cleanup := newTestPath(t)
+	defer cleanup()
+	s, err := sqlstore.Open(driverName, path)
+	common.FatalIfError(t, err, "opening Storage")
+	storagetest.Test(t, s)
+}
+
+func TestStorage_RoundTrip(t *testing.T) {
+	path, cleanup := newTestPath(t)
+	defer cleanup()
+	s, err := sqlstore.Open(driverName, path)
+	common.FatalIfError(t, err, "opening Storage")
+
+	location := time.FixedZone("TEST", 3*60*60)
+	open := time.Date(2000, 1, 2, 3, 4, 5, 6, location)
+	close := open.Add(time.Hour*24*365 + time.Nanosecond)
+	gbp := accountingtest.NewCurrencyCode(t, "GBP")
+	closed, err := s.InsertAccount(*accountingtest.NewAccount(t, "Closed", gbp, open, account.CloseTime(close)))
+	common.FatalIfError(t, err, "inserting closed Account")
+	never, err := s.InsertAccount(*accountingtest.NewAccount(t, "Open", accountingtest.NewCurrencyCode(t, "EUR"), open))
+	common.FatalIfError(t, err, "inserting open Account")
+	b, err := s.InsertBalance(closed.ID, balance.Balance{Date: close, Amount: -1, Currency: gbp})
+	common.FatalIfError(t, err, "inserting Balance")
+	common.FatalIfError(t, s.Close(), "closing Storage")
+
+	s, err = sqlstore.Open(driverName, path)
+	common.FatalIfError(t, err, "reopening Storage")
+	defer func() {
+		common.ErrorIfError(t, s.Close(), "closing Storage")
+	}()
+
+	selected, err := s.SelectAccount(closed.ID)
+	common.FatalIfError(t, err, "selecting closed Account")
+	assert.True(t, closed.Equal(*selected), "Expected: %+v\nActual  : %+v", closed, selected)
+	assert.True(t, selected.Account.Closed().Valid)
+	assert.True(t, selected.Account.Closed().EqualTime(close))
+	assert.Equal(t, gbp, selected.Account.CurrencyCode())
+
+	selected, err = s.SelectAccount(never.ID)
+	common.FatalIfError(t, err, "selecting open Account")
+	assert.True(t, never.Equal(*selected), "Expected: %+v\nActual  : %+v", never, selected)
+	assert.False(t, selected.Account.Closed().Valid)
+	assert.Equal(t, never.Account.CurrencyCode(), selected.Account.CurrencyCode())
+
+	bs, err := s.SelectAccountBalances(closed.ID)
+	common.FatalIfError(t, err, "selecting Balances")
+	if assert.Len(t, bs, 1) {
+		assert.Equal(t, b.ID, bs[0].ID)
+		assert.True(t, b.Balance.Equal(bs[0].Balance), "Expected: %+v\nActual  : %+v", b, bs[0])
+	}
+}
+
+func newTestPath(t *testing.T) (string, func()) {
+	dir, err := ioutil.TempDir("", "sqlstore")
+	common.FatalIfError(t, err, "creating temporary directory")
+	return filepath.Join(dir, "storage.db"), func() {
+		common.ErrorIfError(t, os.RemoveAll(dir), "removing temporary directory")
+	}
+}
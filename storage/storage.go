@@ -0,0 +1,112 @@
+package storage
+
+import (
+	"encoding/json"
+
+	"github.com/glynternet/go-accounting/account"
+	"github.com/glynternet/go-accounting/balance"
+)
+
+// Various error messages describing possible errors when using a Storage.
+const (
+	ErrAccountNotFound = "Account not found"
+	ErrClosed          = "Storage closed"
+)
+
+// Storage is an interface that offers a set of persistence operations for Accounts and their Balances.
+// Implementations of Storage must only store Accounts that have been created
+// through account.New and Balances that have been validated through
+// Account.ValidateBalance, so that the invariants of both hold for stored data.
+type Storage interface {
+	InsertAccount(a account.Account) (*Account, error)
+	SelectAccount(id uint64) (*Account, error)
+	SelectAccounts() (Accounts, error)
+	UpdateAccount(id uint64, updates account.Account) (*Account, error)
+	DeleteAccount(id uint64) error
+	InsertBalance(accountID uint64, b balance.Balance) (*Balance, error)
+	SelectAccountBalances(accountID uint64) (Balances, error)
+	Close() error
+}
+
+// Account holds an account.Account along with the ID that it is stored with.
+type Account struct {
+	ID      uint64
+	Account account.Account
+}
+
+// Equal returns true if two stored Accounts have the same ID and are logically the same.
+func (a Account) Equal(b Account) bool {
+	return a.ID == b.ID && a.Account.Equal(b.Account)
+}
+
+// Accounts holds multiple stored Account items.
+type Accounts []Account
+
+// Balance holds a balance.Balance along with the ID that it is stored with.
+type Balance struct {
+	ID uint64
+	balance.Balance
+}
+
+// MarshalJSON marshals a stored Balance into a json blob.
+// The ID is merged into the object produced by balance.Balance.MarshalJSON, so
+// that a stored Balance is a flat object of the form {"ID":1,"Date":...,"Amount":...}.
+func (b Balance) MarshalJSON() ([]byte, error) {
+	data, err := json.Marshal(b.Balance)
+	if err != nil {
+		return nil, err
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	id, err := json.Marshal(b.ID)
+	if err != nil {
+		return nil, err
+	}
+	fields["ID"] = id
+	return json.Marshal(fields)
+}
+
+// UnmarshalJSON attempts to unmarshal a flat json blob into a stored Balance,
+// returning any errors that occur during the unmarshalling.
+func (b *Balance) UnmarshalJSON(data []byte) error {
+	var aux struct {
+		ID uint64
+	}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	var bb balance.Balance
+	if err := json.Unmarshal(data, &bb); err != nil {
+		return err
+	}
+	b.ID = aux.ID
+	b.Balance = bb
+	return nil
+}
+
+// Balances holds multiple stored Balance items.
+type Balances []Balance
+
+// Balances returns the balance.Balances of the stored Balances.
+func (bs Balances) Balances() balance.Balances {
+	var out balance.Balances
+	for _, b := range bs {
+		out = append(out, b.Balance)
+	}
+	return out
+}
+
+// NewAccount recreates an Account through account.New, returning an error if
+// the Account does not satisfy the invariants enforced by account.New.
+// Implementations of Storage should use NewAccount before storing any Account.
+func NewAccount(a account.Account) (*account.Account, error) {
+	return account.New(
+		a.Name(),
+		a.CurrencyCode(),
+		a.Opened(),
+		account.CloseTime(a.Closed().Time),
+		account.OfType(a.Type()),
+	)
+}
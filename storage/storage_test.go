@@ -0,0 +1,62 @@
+package storage_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/glynternet/go-accounting/account"
+	"github.com/glynternet/go-accounting/accountingtest"
+	"github.com/glynternet/go-accounting/balance"
+	"github.com/glynternet/go-accounting/storage"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewAccount(t *testing.T) {
+	open := time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+	a := accountingtest.NewAccount(t, "A", accountingtest.NewCurrencyCode(t, "GBP"), open,
+		account.CloseTime(open.AddDate(1, 0, 0)),
+		account.OfType(account.Liability),
+	)
+	b, err := storage.NewAccount(*a)
+	assert.Nil(t, err)
+	assert.True(t, a.Equal(*b))
+	assert.Equal(t, a.CurrencyCode(), b.CurrencyCode())
+
+	_, err = storage.NewAccount(account.Account{})
+	assert.EqualError(t, err, account.EmptyNameError)
+}
+
+func TestBalances_Balances(t *testing.T) {
+	now := time.Now()
+	assert.Nil(t, storage.Balances{}.Balances())
+	assert.Equal(t, balance.Balances{
+		{Date: now, Amount: 1},
+		{Date: now, Amount: 2},
+	}, storage.Balances{
+		{ID: 1, Balance: balance.Balance{Date: now, Amount: 1}},
+		{ID: 2, Balance: balance.Balance{Date: now, Amount: 2}},
+	}.Balances())
+}
+
+func TestBalance_JSON(t *testing.T) {
+	b := storage.Balance{
+		ID:      7,
+		Balance: balance.Balance{Date: time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC), Amount: 12, Currency: accountingtest.NewCurrencyCode(t, "GBP")},
+	}
+	data, err := json.Marshal(b)
+	assert.Nil(t, err)
+	assert.JSONEq(t, `{"ID":7,"Date":"2000-01-01T00:00:00Z","Amount":12,"Currency":"GBP"}`, string(data))
+
+	var unmarshalled storage.Balance
+	assert.Nil(t, json.Unmarshal(data, &unmarshalled))
+	assert.Equal(t, b.ID, unmarshalled.ID)
+	assert.True(t, b.Balance.Equal(unmarshalled.Balance))
+
+	// Balances stored before balance.Balance had its own json codec have an
+	// empty Currency field.
+	var legacy storage.Balance
+	assert.Nil(t, json.Unmarshal([]byte(`{"ID":3,"Date":"2000-01-01T00:00:00Z","Amount":5,"Currency":""}`), &legacy))
+	assert.Equal(t, uint64(3), legacy.ID)
+	assert.True(t, balance.Balance{Date: time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC), Amount: 5}.Equal(legacy.Balance))
+}
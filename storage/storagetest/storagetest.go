@@ -0,0 +1,120 @@
+package storagetest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/glynternet/go-accounting/account"
+	"github.com/glynternet/go-accounting/accountingtest"
+	"github.com/glynternet/go-accounting/balance"
+	"github.com/glynternet/go-accounting/storage"
+	"github.com/glynternet/go-money/common"
+	"github.com/stretchr/testify/assert"
+)
+
+// Test runs a suite of tests against a Storage, checking that it behaves as
+// described by the storage.Storage interface.
+// The Storage given to Test must be empty and is closed by the end of the suite.
+func Test(t *testing.T, s storage.Storage) {
+	open := time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+	close := open.AddDate(1, 0, 0)
+	gbp := accountingtest.NewCurrencyCode(t, "GBP")
+
+	as, err := s.SelectAccounts()
+	common.FatalIfError(t, err, "selecting Accounts of empty Storage")
+	assert.Empty(t, as)
+
+	_, err = s.InsertAccount(account.Account{})
+	assert.EqualError(t, err, account.EmptyNameError, "inserting zero-value Account")
+
+	a := accountingtest.NewAccount(t, "A", gbp, open, account.CloseTime(close), account.OfType(account.Asset))
+	storedA, err := s.InsertAccount(*a)
+	common.FatalIfError(t, err, "inserting Account A")
+	assert.True(t, storedA.Account.Equal(*a))
+
+	b := accountingtest.NewAccount(t, "B", gbp, open)
+	storedB, err := s.InsertAccount(*b)
+	common.FatalIfError(t, err, "inserting Account B")
+	assert.NotEqual(t, storedA.ID, storedB.ID)
+
+	selected, err := s.SelectAccount(storedA.ID)
+	common.FatalIfError(t, err, "selecting Account A")
+	assertAccountsEqual(t, *storedA, *selected)
+	assert.Equal(t, a.CurrencyCode(), selected.Account.CurrencyCode())
+	assert.Equal(t, account.Asset, selected.Account.Type())
+
+	_, err = s.SelectAccount(storedB.ID + 1000)
+	assert.EqualError(t, err, storage.ErrAccountNotFound)
+
+	as, err = s.SelectAccounts()
+	common.FatalIfError(t, err, "selecting Accounts")
+	if assert.Len(t, as, 2) {
+		assertAccountsEqual(t, *storedA, as[0])
+		assertAccountsEqual(t, *storedB, as[1])
+	}
+
+	first, err := s.InsertBalance(storedA.ID, balance.Balance{Date: open, Amount: 100})
+	common.FatalIfError(t, err, "inserting Balance at Account open")
+	second, err := s.InsertBalance(storedA.ID, balance.Balance{Date: close, Amount: -50, Currency: gbp})
+	common.FatalIfError(t, err, "inserting Balance at Account close")
+	assert.NotEqual(t, first.ID, second.ID)
+
+	_, err = s.InsertBalance(storedA.ID, balance.Balance{Date: close.Add(time.Second)})
+	assert.IsType(t, balance.DateOutOfAccountTimeRange{}, err, "inserting Balance after Account close")
+	_, err = s.InsertBalance(storedA.ID, balance.Balance{Date: open, Currency: accountingtest.NewCurrencyCode(t, "EUR")})
+	assert.IsType(t, balance.CurrencyMismatch{}, err, "inserting Balance of other currency")
+	_, err = s.InsertBalance(storedB.ID+1000, balance.Balance{Date: open})
+	assert.EqualError(t, err, storage.ErrAccountNotFound)
+
+	bs, err := s.SelectAccountBalances(storedA.ID)
+	common.FatalIfError(t, err, "selecting Balances of Account A")
+	if assert.Len(t, bs, 2) {
+		assertBalancesEqual(t, *first, bs[0])
+		assertBalancesEqual(t, *second, bs[1])
+	}
+	bs, err = s.SelectAccountBalances(storedB.ID)
+	common.FatalIfError(t, err, "selecting Balances of Account B")
+	assert.Empty(t, bs)
+
+	shortened := accountingtest.NewAccount(t, "A", gbp, open, account.CloseTime(close.AddDate(0, -1, 0)))
+	_, err = s.UpdateAccount(storedA.ID, *shortened)
+	assert.IsType(t, balance.DateOutOfAccountTimeRange{}, err, "updating Account to exclude stored Balances")
+	selected, err = s.SelectAccount(storedA.ID)
+	common.FatalIfError(t, err, "selecting Account A after failed update")
+	assertAccountsEqual(t, *storedA, *selected)
+
+	renamed := accountingtest.NewAccount(t, "Renamed", gbp, open, account.CloseTime(close))
+	updated, err := s.UpdateAccount(storedA.ID, *renamed)
+	common.FatalIfError(t, err, "updating Account A")
+	assert.Equal(t, storedA.ID, updated.ID)
+	selected, err = s.SelectAccount(storedA.ID)
+	common.FatalIfError(t, err, "selecting Account A after update")
+	assertAccountsEqual(t, *updated, *selected)
+	assert.Equal(t, "Renamed", selected.Account.Name())
+
+	_, err = s.UpdateAccount(storedB.ID+1000, *renamed)
+	assert.EqualError(t, err, storage.ErrAccountNotFound)
+
+	common.FatalIfError(t, s.DeleteAccount(storedA.ID), "deleting Account A")
+	_, err = s.SelectAccount(storedA.ID)
+	assert.EqualError(t, err, storage.ErrAccountNotFound)
+	_, err = s.SelectAccountBalances(storedA.ID)
+	assert.EqualError(t, err, storage.ErrAccountNotFound)
+	assert.EqualError(t, s.DeleteAccount(storedA.ID), storage.ErrAccountNotFound)
+	as, err = s.SelectAccounts()
+	common.FatalIfError(t, err, "selecting Accounts after delete")
+	if assert.Len(t, as, 1) {
+		assertAccountsEqual(t, *storedB, as[0])
+	}
+
+	common.FatalIfError(t, s.Close(), "closing Storage")
+}
+
+func assertAccountsEqual(t *testing.T, expected, actual storage.Account) {
+	assert.True(t, expected.Equal(actual), "Expected: %+v\nActual  : %+v", expected, actual)
+}
+
+func assertBalancesEqual(t *testing.T, expected, actual storage.Balance) {
+	assert.Equal(t, expected.ID, actual.ID)
+	assert.True(t, expected.Balance.Equal(actual.Balance), "Expected: %+v\nActual  : %+v", expected, actual)
+}
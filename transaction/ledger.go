@@ -0,0 +1,87 @@
+package transaction
+
+import (
+	"errors"
+
+	"github.com/glynternet/go-accounting/account"
+	"github.com/glynternet/go-accounting/balance"
+)
+
+// ErrAccountMismatch is the error message used when a Transaction references
+// an Account that is not the Account of the Ledger it is being posted to.
+const ErrAccountMismatch = "Transaction Account does not match Ledger Account"
+
+// NewLedger creates a new Ledger for the given Account, posting each of the
+// given Transactions to it in turn.
+// NewLedger returns an error if any of the Transactions cannot be posted.
+func NewLedger(a account.Account, ts ...Transaction) (*Ledger, error) {
+	l := &Ledger{account: a}
+	for _, t := range ts {
+		if err := l.Post(t); err != nil {
+			return nil, err
+		}
+	}
+	return l, nil
+}
+
+// Ledger holds the Transactions that have been posted to a single Account.
+type Ledger struct {
+	account      account.Account
+	transactions Transactions
+}
+
+// Account returns the Account that the Ledger holds Transactions for.
+func (l Ledger) Account() account.Account {
+	return l.account
+}
+
+// Transactions returns a copy of the Transactions posted to the Ledger, in
+// the order that they were posted.
+func (l Ledger) Transactions() Transactions {
+	ts := make(Transactions, len(l.transactions))
+	copy(ts, l.transactions)
+	return ts
+}
+
+// Post adds a Transaction to the Ledger.
+// Post returns an error if the Transaction references a different Account to
+// that of the Ledger or if the Transaction could not be validated against the
+// Account through Account.ValidateBalance. When the Transaction is invalid,
+// the Ledger is not altered.
+func (l *Ledger) Post(t Transaction) error {
+	if t.Account != nil && !t.Account.Equal(l.account) {
+		return errors.New(ErrAccountMismatch)
+	}
+	err := l.account.ValidateBalance(balance.Balance{Date: t.Date, Amount: t.Amount})
+	if err != nil {
+		return err
+	}
+	l.transactions = append(l.transactions, t)
+	return nil
+}
+
+// Balances replays the Transactions of the Ledger in Date order and returns
+// the running Balance of the Account after each Transaction, in the currency
+// of the Account.
+// Transactions that share the same Date are replayed in the order that they
+// were posted, so the last Balance for a given Date will always be the
+// Balance at the end of that Date, consistent with Balances.AtTime.
+// Each Balance is validated through Account.ValidateBalance and the first
+// validation error encountered is returned.
+func (l Ledger) Balances() (balance.Balances, error) {
+	var bs balance.Balances
+	var running int
+	for _, t := range l.transactions.Sorted() {
+		running += t.Amount
+		b := balance.Balance{
+			Date:     t.Date,
+			Amount:   running,
+			Currency: l.account.CurrencyCode(),
+		}
+		if err := l.account.ValidateBalance(b); err != nil {
+			return nil, err
+		}
+		bs = append(bs, b)
+	}
+	return bs, nil
+}
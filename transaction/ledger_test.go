@@ -0,0 +1,117 @@
+package transaction_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/glynternet/go-accounting/account"
+	"github.com/glynternet/go-accounting/balance"
+	"github.com/glynternet/go-accounting/transaction"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewLedger(t *testing.T) {
+	a := newTestAccount(t, "A", account.CloseTime(newTestDate(2010)))
+
+	l, err := transaction.NewLedger(a)
+	assert.Nil(t, err)
+	assert.True(t, l.Account().Equal(a))
+	assert.Empty(t, l.Transactions())
+
+	valid := newTestTransaction(t, 2000, transaction.Amount(10))
+	l, err = transaction.NewLedger(a, valid)
+	assert.Nil(t, err)
+	assert.Equal(t, transaction.Transactions{valid}, l.Transactions())
+
+	l, err = transaction.NewLedger(a, valid, newTestTransaction(t, 2020))
+	assert.IsType(t, balance.DateOutOfAccountTimeRange{}, err)
+	assert.Nil(t, l)
+}
+
+func TestLedger_Post(t *testing.T) {
+	a := newTestAccount(t, "A", account.CloseTime(newTestDate(2010)))
+	for _, test := range []struct {
+		name string
+		transaction.Transaction
+		err error
+	}{
+		{
+			name:        "within account time range",
+			Transaction: newTestTransaction(t, 2000, transaction.Amount(1)),
+		},
+		{
+			name:        "at account close time",
+			Transaction: newTestTransaction(t, 2010, transaction.Amount(1)),
+		},
+		{
+			name:        "referencing ledger account",
+			Transaction: newTestTransaction(t, 2000, transaction.Account(a)),
+		},
+		{
+			name:        "before account open",
+			Transaction: newTestTransaction(t, 900),
+			err: balance.DateOutOfAccountTimeRange{
+				BalanceDate:      newTestDate(900),
+				AccountTimeRange: a.TimeRange(),
+			},
+		},
+		{
+			name:        "after account close",
+			Transaction: newTestTransaction(t, 2011),
+			err: balance.DateOutOfAccountTimeRange{
+				BalanceDate:      newTestDate(2011),
+				AccountTimeRange: a.TimeRange(),
+			},
+		},
+		{
+			name:        "referencing other account",
+			Transaction: newTestTransaction(t, 2000, transaction.Account(newTestAccount(t, "B"))),
+			err:         errors.New(transaction.ErrAccountMismatch),
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			l, err := transaction.NewLedger(a)
+			assert.Nil(t, err)
+			err = l.Post(test.Transaction)
+			assert.Equal(t, test.err, err)
+			if test.err != nil {
+				assert.Empty(t, l.Transactions())
+				return
+			}
+			assert.Equal(t, transaction.Transactions{test.Transaction}, l.Transactions())
+		})
+	}
+}
+
+func TestLedger_Balances(t *testing.T) {
+	a := newTestAccount(t, "A")
+	l, err := transaction.NewLedger(a)
+	assert.Nil(t, err)
+
+	bs, err := l.Balances()
+	assert.Nil(t, err)
+	assert.Empty(t, bs)
+
+	for _, tr := range []transaction.Transaction{
+		newTestTransaction(t, 2002, transaction.Amount(-5)),
+		newTestTransaction(t, 2000, transaction.Amount(100)),
+		newTestTransaction(t, 2001, transaction.Amount(20)),
+		newTestTransaction(t, 2000, transaction.Amount(-30)),
+	} {
+		assert.Nil(t, l.Post(tr))
+	}
+
+	bs, err = l.Balances()
+	assert.Nil(t, err)
+	gbp := a.CurrencyCode()
+	assert.Equal(t, balance.Balances{
+		{Date: newTestDate(2000), Amount: 100, Currency: gbp},
+		{Date: newTestDate(2000), Amount: 70, Currency: gbp},
+		{Date: newTestDate(2001), Amount: 90, Currency: gbp},
+		{Date: newTestDate(2002), Amount: 85, Currency: gbp},
+	}, bs)
+
+	at, err := bs.AtTime(newTestDate(2000))
+	assert.Nil(t, err)
+	assert.Equal(t, 70, at.Amount)
+}
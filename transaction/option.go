@@ -0,0 +1,48 @@
+package transaction
+
+import "github.com/glynternet/go-accounting/account"
+
+// Option is a function that takes a pointer to a Transaction returning an error.
+// The idea of Option is to alter a Transaction object
+type Option func(*Transaction) error
+
+// Amount is an Option that will alter the Amount of a Transaction object.
+func Amount(a int) Option {
+	return func(t *Transaction) error {
+		t.Amount = a
+		return nil
+	}
+}
+
+// Description is an Option that will alter the Description of a Transaction object.
+func Description(d string) Option {
+	return func(t *Transaction) error {
+		t.Description = d
+		return nil
+	}
+}
+
+// Payee is an Option that will alter the Payee of a Transaction object.
+func Payee(p string) Option {
+	return func(t *Transaction) error {
+		t.Payee = p
+		return nil
+	}
+}
+
+// Category is an Option that will alter the Category of a Transaction object.
+func Category(c string) Option {
+	return func(t *Transaction) error {
+		t.Category = c
+		return nil
+	}
+}
+
+// Account is an Option that will set the Account that a Transaction object is
+// posted to.
+func Account(a account.Account) Option {
+	return func(t *Transaction) error {
+		t.Account = &a
+		return nil
+	}
+}
@@ -0,0 +1,57 @@
+package transaction_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/glynternet/go-accounting/transaction"
+	"github.com/glynternet/go-money/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAmount(t *testing.T) {
+	tr, err := transaction.New(time.Now())
+	common.FatalIfError(t, err, "Creating transaction")
+	assert.Nil(t, transaction.Amount(-645)(tr))
+	assert.Equal(t, -645, tr.Amount)
+}
+
+func TestDescription(t *testing.T) {
+	tr, err := transaction.New(time.Now())
+	common.FatalIfError(t, err, "Creating transaction")
+	assert.Nil(t, transaction.Description("groceries")(tr))
+	assert.Equal(t, "groceries", tr.Description)
+}
+
+func TestPayee(t *testing.T) {
+	tr, err := transaction.New(time.Now())
+	common.FatalIfError(t, err, "Creating transaction")
+	assert.Nil(t, transaction.Payee("Tesco")(tr))
+	assert.Equal(t, "Tesco", tr.Payee)
+}
+
+func TestCategory(t *testing.T) {
+	tr, err := transaction.New(time.Now())
+	common.FatalIfError(t, err, "Creating transaction")
+	assert.Nil(t, transaction.Category("household")(tr))
+	assert.Equal(t, "household", tr.Category)
+}
+
+func TestAccount(t *testing.T) {
+	tr, err := transaction.New(time.Now())
+	common.FatalIfError(t, err, "Creating transaction")
+	a := newTestAccount(t, "A")
+	assert.Nil(t, transaction.Account(a)(tr))
+	if assert.NotNil(t, tr.Account) {
+		assert.True(t, tr.Account.Equal(a))
+	}
+}
+
+func TestErrorOption(t *testing.T) {
+	errorFn := func(*transaction.Transaction) error {
+		return errors.New("TEST ERROR")
+	}
+	_, err := transaction.New(time.Now(), errorFn)
+	assert.Equal(t, errors.New("TEST ERROR"), err)
+}
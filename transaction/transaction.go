@@ -0,0 +1,78 @@
+package transaction
+
+import (
+	"sort"
+	"time"
+
+	"github.com/glynternet/go-accounting/account"
+)
+
+// New creates a new Transaction
+func New(date time.Time, options ...Option) (t *Transaction, err error) {
+	tt := Transaction{Date: date}
+	for _, o := range options {
+		err = o(&tt)
+		if err != nil {
+			return
+		}
+	}
+	t = &tt
+	return
+}
+
+// Transaction holds the logic for a single movement of money into or out of
+// an Account.
+type Transaction struct {
+	Date        time.Time
+	Amount      int
+	Description string
+	Payee       string
+	Category    string
+	Account     *account.Account
+}
+
+// Equal returns true if two Transaction objects are logically equal.
+// Two Transactions are only equal if they both reference no Account, or they
+// reference Accounts that are logically equal.
+func (t Transaction) Equal(ot Transaction) bool {
+	switch {
+	case t.Amount != ot.Amount:
+		return false
+	case !t.Date.Equal(ot.Date):
+		return false
+	case t.Description != ot.Description:
+		return false
+	case t.Payee != ot.Payee:
+		return false
+	case t.Category != ot.Category:
+		return false
+	case (t.Account == nil) != (ot.Account == nil):
+		return false
+	case t.Account != nil && !t.Account.Equal(*ot.Account):
+		return false
+	}
+	return true
+}
+
+// Transactions holds multiple Transaction items.
+type Transactions []Transaction
+
+// Sum returns the value of all of the Transactions summed together.
+func (ts Transactions) Sum() (s int) {
+	for _, t := range ts {
+		s += t.Amount
+	}
+	return
+}
+
+// Sorted returns a copy of the Transactions ordered by Date.
+// Transactions with the same Date retain the order in which they were
+// encountered.
+func (ts Transactions) Sorted() Transactions {
+	sorted := make(Transactions, len(ts))
+	copy(sorted, ts)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Date.Before(sorted[j].Date)
+	})
+	return sorted
+}
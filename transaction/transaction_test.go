@@ -0,0 +1,116 @@
+package transaction_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/glynternet/go-accounting/account"
+	"github.com/glynternet/go-accounting/accountingtest"
+	"github.com/glynternet/go-accounting/transaction"
+	"github.com/glynternet/go-money/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNew(t *testing.T) {
+	now := time.Now()
+	tr, err := transaction.New(now)
+	assert.Nil(t, err)
+	assert.Equal(t, now, tr.Date)
+	assert.Equal(t, 0, tr.Amount)
+	assert.Empty(t, tr.Description)
+	assert.Nil(t, tr.Account)
+}
+
+func TestTransaction_Equal(t *testing.T) {
+	year := 300
+	a := newTestAccount(t, "A")
+	base := newTestTransaction(t, year, transaction.Amount(123), transaction.Description("rent"))
+	for _, test := range []struct {
+		name  string
+		b     transaction.Transaction
+		equal bool
+	}{
+		{
+			name:  "equal",
+			b:     newTestTransaction(t, year, transaction.Amount(123), transaction.Description("rent")),
+			equal: true,
+		},
+		{
+			name: "different amount",
+			b:    newTestTransaction(t, year, transaction.Amount(-123), transaction.Description("rent")),
+		},
+		{
+			name: "different time",
+			b:    newTestTransaction(t, year+1, transaction.Amount(123), transaction.Description("rent")),
+		},
+		{
+			name: "different description",
+			b:    newTestTransaction(t, year, transaction.Amount(123), transaction.Description("food")),
+		},
+		{
+			name: "different payee",
+			b:    newTestTransaction(t, year, transaction.Amount(123), transaction.Description("rent"), transaction.Payee("landlord")),
+		},
+		{
+			name: "different category",
+			b:    newTestTransaction(t, year, transaction.Amount(123), transaction.Description("rent"), transaction.Category("housing")),
+		},
+		{
+			name: "with account",
+			b:    newTestTransaction(t, year, transaction.Amount(123), transaction.Description("rent"), transaction.Account(a)),
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.equal, base.Equal(test.b))
+			assert.Equal(t, test.equal, test.b.Equal(base))
+		})
+	}
+
+	withA := newTestTransaction(t, year, transaction.Account(a))
+	assert.True(t, withA.Equal(newTestTransaction(t, year, transaction.Account(a))))
+	assert.False(t, withA.Equal(newTestTransaction(t, year, transaction.Account(newTestAccount(t, "B")))))
+}
+
+func TestTransactions_Sum(t *testing.T) {
+	for _, test := range []struct {
+		amounts []int
+		sum     int
+	}{
+		{},
+		{amounts: []int{1}, sum: 1},
+		{amounts: []int{1, 2}, sum: 3},
+		{amounts: []int{1, 2, -3}, sum: 0},
+	} {
+		var ts transaction.Transactions
+		for _, a := range test.amounts {
+			ts = append(ts, newTestTransaction(t, 2000, transaction.Amount(a)))
+		}
+		assert.Equal(t, test.sum, ts.Sum())
+	}
+}
+
+func TestTransactions_Sorted(t *testing.T) {
+	ts := transaction.Transactions{
+		newTestTransaction(t, 2002, transaction.Amount(1)),
+		newTestTransaction(t, 2000, transaction.Amount(2)),
+		newTestTransaction(t, 2001, transaction.Amount(3)),
+		newTestTransaction(t, 2000, transaction.Amount(4)),
+	}
+	sorted := ts.Sorted()
+	assert.Equal(t, transaction.Transactions{ts[1], ts[3], ts[2], ts[0]}, sorted)
+	assert.Equal(t, 1, ts[0].Amount, "original Transactions should not be altered")
+}
+
+func newTestTransaction(t *testing.T, year int, options ...transaction.Option) transaction.Transaction {
+	tr, err := transaction.New(newTestDate(year), options...)
+	common.FatalIfError(t, err, "Creating new Transaction")
+	return *tr
+}
+
+func newTestDate(year int) time.Time {
+	return time.Date(year, 1, 1, 1, 1, 1, 1, time.UTC)
+}
+
+func newTestAccount(t *testing.T, name string, os ...account.Option) account.Account {
+	return *accountingtest.NewAccount(t, name, accountingtest.NewCurrencyCode(t, "GBP"), newTestDate(1000), os...)
+}